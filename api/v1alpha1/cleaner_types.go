@@ -25,7 +25,7 @@ import (
 )
 
 // Action specifies the action to take on matching resources
-// +kubebuilder:validation:Enum:=Delete;Transform;Scan
+// +kubebuilder:validation:Enum:=Delete;Transform;Scan;Scale;Evict;Drain;Label;Webhook
 type Action string
 
 const (
@@ -37,12 +37,52 @@ const (
 
 	// ActionScan will identify matching objects. No action is taken on those.
 	ActionScan = Action("Scan")
+
+	// ActionScale will change the replica count of matching objects through
+	// their scale subresource.
+	ActionScale = Action("Scale")
+
+	// ActionEvict will evict matching Pods through the Eviction API, honoring
+	// PodDisruptionBudgets.
+	ActionEvict = Action("Evict")
+
+	// ActionDrain will cordon matching Nodes and evict their Pods, honoring
+	// PodDisruptionBudgets.
+	ActionDrain = Action("Drain")
+
+	// ActionLabel will add or remove labels and annotations of matching
+	// objects through a patch.
+	ActionLabel = Action("Label")
+
+	// ActionWebhook will POST each matching object to an external HTTP
+	// endpoint.
+	ActionWebhook = Action("Webhook")
 )
 
 const (
 	// CleanerFinalizer allows Reconciler to clean up resources associated with
 	// Cleaner instance before removing it from the apiserver.
 	CleanerFinalizer = "projectsveltos.io/cleaner-finalizer"
+
+	// ProtectAnnotation, when set on a resource, prevents Cleaners from taking
+	// any Action on it but Scan (Delete, Transform, Scale, Evict, Drain, Label
+	// or Webhook), whatever their Evaluate function returns.
+	// Value "true" protects the resource from every Cleaner; a comma separated
+	// list of Cleaner names protects it from those Cleaners only.
+	ProtectAnnotation = "cleaner.projectsveltos.io/protect"
+
+	// ProtectUntilAnnotation is an optional RFC3339 timestamp after which the
+	// protection granted by ProtectAnnotation expires.
+	ProtectUntilAnnotation = "cleaner.projectsveltos.io/protect-until"
+
+	// DeleteAfterAnnotation is set by a two-phase Delete on the resources it
+	// scheduled for deletion. Its value is the RFC3339 time after which the
+	// resource is deleted if it still matches.
+	DeleteAfterAnnotation = "cleaner.projectsveltos.io/delete-after"
+
+	// ScheduledByAnnotation is set along with DeleteAfterAnnotation to the name
+	// of the Cleaner that scheduled the deletion.
+	ScheduledByAnnotation = "cleaner.projectsveltos.io/scheduled-by"
 )
 
 // DeleteOptions contains options for delete requests. It's generally a subset
@@ -65,6 +105,110 @@ type DeleteOptions struct {
 	// foreground.
 	// +optional
 	PropagationPolicy *metav1.DeletionPropagation `json:"propagationPolicy,omitempty"`
+
+	// TwoPhase, when set, makes Delete two-phase. A matching resource is first
+	// annotated as scheduled for deletion and is only deleted by a later run,
+	// once GracePeriod has elapsed, if it still matches. Removing the
+	// annotation before then rescues the resource.
+	// +optional
+	TwoPhase *TwoPhaseDelete `json:"twoPhase,omitempty"`
+}
+
+// ScaleOptions configures the Scale action.
+type ScaleOptions struct {
+	// Replicas is the replica count matching resources are scaled to. The
+	// evaluate function can override it per resource by returning a
+	// "replicas" field. A resource with no replica count from either is
+	// reported as failed.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+}
+
+// EvictOptions configures the Evict action.
+type EvictOptions struct {
+	// GracePeriodSeconds is the duration in seconds evicted Pods are given to
+	// terminate. If nil, the Pod's own termination grace period is used.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+}
+
+// DrainOptions configures the Drain action.
+type DrainOptions struct {
+	// Timeout is how long a run waits for the Pods of a Node to be evicted.
+	// Pods still there afterwards, for instance because a PodDisruptionBudget
+	// blocks their eviction, are evicted by later runs while the Node stays
+	// cordoned. Zero means evictions are requested once, without waiting.
+	// Defaults to 5m.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// GracePeriodSeconds is the duration in seconds evicted Pods are given to
+	// terminate. If nil, each Pod's own termination grace period is used.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+
+	// DeleteNode, when set, deletes the Node once all its Pods are evicted.
+	// +optional
+	DeleteNode bool `json:"deleteNode,omitempty"`
+}
+
+// LabelOptions configures the Label action. Values of added labels and
+// annotations are Go templates, executed with the fields Message (the message
+// returned by the evaluate function), Name, Namespace and Kind of the
+// matching resource. Keys both added and removed are added.
+type LabelOptions struct {
+	// AddLabels are the labels set on matching resources.
+	// +optional
+	AddLabels map[string]string `json:"addLabels,omitempty"`
+
+	// RemoveLabels are the keys of the labels removed from matching resources.
+	// +optional
+	RemoveLabels []string `json:"removeLabels,omitempty"`
+
+	// AddAnnotations are the annotations set on matching resources.
+	// +optional
+	AddAnnotations map[string]string `json:"addAnnotations,omitempty"`
+
+	// RemoveAnnotations are the keys of the annotations removed from matching
+	// resources.
+	// +optional
+	RemoveAnnotations []string `json:"removeAnnotations,omitempty"`
+}
+
+// WebhookOptions configures the Webhook action.
+type WebhookOptions struct {
+	// URL is the HTTP(S) endpoint each matching resource is POSTed to, as
+	// JSON, along with the message returned by the evaluate function and the
+	// Cleaner metadata.
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+
+	// SecretRef optionally references a Secret containing credentials to
+	// authenticate against the endpoint.
+	// Supported keys: "token" (bearer), "username"+"password" (basic auth).
+	// +optional
+	SecretRef *corev1.SecretReference `json:"secretRef,omitempty"`
+
+	// Timeout is the timeout of each request. Defaults to 30s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// MaxRetries is how many times a request failing because of a network
+	// error, a 429 or a 5xx response is retried, with exponential backoff.
+	// Defaults to 3.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+}
+
+// TwoPhaseDelete configures a Delete that first marks resources and deletes
+// them only after a grace period.
+type TwoPhaseDelete struct {
+	// GracePeriod is how long a resource stays marked before it is deleted.
+	GracePeriod metav1.Duration `json:"gracePeriod"`
 }
 
 // MetricSource identifies a Prometheus-compatible metrics endpoint reachable
@@ -121,6 +265,24 @@ type LogSource struct {
 	Previous bool `json:"previous,omitempty"`
 }
 
+// OwnerFilter selects resources based on their owner references.
+// All set fields must be satisfied.
+type OwnerFilter struct {
+	// HasOwner, if set, requires resources to have at least one owner
+	// reference (true) or none at all (false).
+	// +optional
+	HasOwner *bool `json:"hasOwner,omitempty"`
+
+	// Kind requires resources to have at least one owner of this Kind.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// OwnerMissing, when true, requires at least one owner of the resource
+	// (of Kind, if set) to no longer exist in the cluster.
+	// +optional
+	OwnerMissing bool `json:"ownerMissing,omitempty"`
+}
+
 type ResourceSelector struct {
 	// Namespace of the resource deployed in the  Cluster.
 	// Empty for resources scoped at cluster level.
@@ -131,6 +293,16 @@ type ResourceSelector struct {
 	// +optional
 	NamespaceSelector string `json:"namespaceSelector,omitempty"`
 
+	// ExcludeNamespaces lists namespaces whose resources are never selected,
+	// even if they match Namespace or NamespaceSelector.
+	// +optional
+	ExcludeNamespaces []string `json:"excludeNamespaces,omitempty"`
+
+	// ExcludeNamespaceSelector is a label selector for namespaces whose
+	// resources are never selected.
+	// +optional
+	ExcludeNamespaceSelector string `json:"excludeNamespaceSelector,omitempty"`
+
 	// Group of the resource deployed in the Cluster.
 	Group string `json:"group"`
 
@@ -144,11 +316,27 @@ type ResourceSelector struct {
 	// LabelFilters allows to filter resources based on current labels.
 	LabelFilters []libsveltosv1beta1.LabelFilter `json:"labelFilters,omitempty"`
 
+	// FieldSelector filters resources by field, using the same syntax as
+	// kubectl --field-selector (e.g. status.phase=Failed). Only fields
+	// supported by the API server for Kind can be used.
+	// +optional
+	FieldSelector string `json:"fieldSelector,omitempty"`
+
+	// OwnerFilter filters resources based on their owner references.
+	// +optional
+	OwnerFilter *OwnerFilter `json:"ownerFilter,omitempty"`
+
+	// OlderThan, if set, only selects resources created more than OlderThan ago.
+	// +optional
+	OlderThan *metav1.Duration `json:"olderThan,omitempty"`
+
 	// Evaluate contains a function "evaluate" in lua language.
 	// The function will be passed one of the object selected based on
 	// above criteria.
 	// Must return struct with field "matching" representing whether
-	// object is a match and an optional "message" field.
+	// object is a match and an optional "message" field. When Action is
+	// Scale, an optional "replicas" field sets the replica count the object
+	// is scaled to.
 	// The global metrics table is available when MetricSource is set.
 	// The global events table is available when IncludeEvents is set.
 	// The global logs (and logsByContainer) table is available when LogSource is set.
@@ -187,6 +375,22 @@ type ResourceSelector struct {
 	// string).
 	// +optional
 	LogSource *LogSource `json:"logSource,omitempty"`
+
+	// UseCache, when true, reads the resources selected by this ResourceSelector
+	// from an informer cache shared by all Cleaners instead of listing them from
+	// the API server on every run. The cache is started the first time a Cleaner
+	// needs it and stopped once no Cleaner uses it anymore. Resources read from
+	// the cache may be slightly stale.
+	// +kubebuilder:default:=false
+	// +optional
+	UseCache bool `json:"useCache,omitempty"`
+
+	// PageSize is the number of resources requested per List call. Resources
+	// are evaluated as each page is received and only matching ones are kept
+	// in memory. Defaults to 500. Ignored when resources are read from the cache.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PageSize *int64 `json:"pageSize,omitempty"`
 }
 
 type ResourcePolicySet struct {
@@ -257,7 +461,12 @@ type CleanerSpec struct {
 
 	// Action indicates the action to take on selected object. Default action
 	// is to delete object. If set to transform, the transform function
-	// will be invoked and then object will be updated.
+	// will be invoked and then object will be updated. If set to scale, the
+	// object replica count is changed through its scale subresource. If set
+	// to evict, Pods are evicted honoring PodDisruptionBudgets. If set to
+	// drain, Nodes are cordoned and their Pods evicted. If set to label,
+	// labels and annotations are added or removed through a patch. If set to
+	// webhook, each object is POSTed to an external HTTP endpoint.
 	// +kubebuilder:default:=Delete
 	Action Action `json:"action,omitempty"`
 
@@ -270,10 +479,56 @@ type CleanerSpec struct {
 	// When Action is set to *Transform*, this function will be invoked
 	// and be passed one of the object selected based on
 	// above criteria.
-	// Must the new object that will be applied
+	// Must return a table with exactly one of the following fields:
+	// - "resource": the new object, sent with a full Update;
+	// - "jsonPatch": a list of RFC 6902 JSON patch operations;
+	// - "mergePatch": an RFC 7386 JSON merge patch;
+	// - "applyConfiguration": a partial object, sent with server-side apply.
 	// +optional
 	Transform string `json:"transform,omitempty"`
 
+	// TransformOptions configures how the output of the transform function
+	// is sent to the API server. Only used when Action is Transform.
+	// +optional
+	TransformOptions *TransformOptions `json:"transformOptions,omitempty"`
+
+	// ScaleOptions configures the Scale action. Only used when Action is Scale.
+	// +optional
+	ScaleOptions *ScaleOptions `json:"scaleOptions,omitempty"`
+
+	// EvictOptions configures the Evict action. Only used when Action is Evict.
+	// +optional
+	EvictOptions *EvictOptions `json:"evictOptions,omitempty"`
+
+	// DrainOptions configures the Drain action. Only used when Action is Drain.
+	// +optional
+	DrainOptions *DrainOptions `json:"drainOptions,omitempty"`
+
+	// LabelOptions configures the Label action. Only used when Action is Label.
+	// +optional
+	LabelOptions *LabelOptions `json:"labelOptions,omitempty"`
+
+	// WebhookOptions configures the Webhook action. Only used when Action is
+	// Webhook.
+	// +optional
+	WebhookOptions *WebhookOptions `json:"webhookOptions,omitempty"`
+
+	// Actions, when set, is an ordered list of steps taken on matching
+	// resources, each with its own optional evaluate function and options.
+	// A step only sees the resources earlier steps processed, skipped or did
+	// not select, and can look at their outcome. When set, Action and the
+	// action options above are ignored. Rollback is not supported for
+	// Cleaners with Actions.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Actions []ActionStep `json:"actions,omitempty"`
+
+	// Execution paces the Action, so that acting on many resources does not
+	// put pressure on the API server.
+	// +optional
+	Execution *ExecutionOptions `json:"execution,omitempty"`
+
 	// Schedule in Cron format, see https://en.wikipedia.org/wiki/Cron.
 	Schedule string `json:"schedule"`
 
@@ -311,11 +566,198 @@ type CleanerSpec struct {
 	BlastRadiusLimit *BlastRadiusLimit `json:"blastRadiusLimit,omitempty"`
 
 	// Rollback, when set, captures the pre-action state of resources affected by
-	// a Delete or Transform action, so the most recent execution can be reverted.
+	// a Delete, Transform or Label action, so the most recent execution can be
+	// reverted.
 	// Capturing this state requires a CleanerReport Notification to also be
 	// configured, since captured resources are persisted on the Report instance.
 	// +optional
 	Rollback *RollbackOptions `json:"rollback,omitempty"`
+
+	// DryRun, when set, runs the full pipeline (selection, AggregatedSelection,
+	// OccurrenceThreshold, BlastRadiusLimit and, for Transform, the transform
+	// function) but sends Delete and Update requests to the API server in
+	// server-side dry-run mode. Requests are validated and admitted as usual,
+	// but nothing is persisted. Outcomes, and for Transform the would-be diff,
+	// are reported via Notifications so a Cleaner can be reviewed before it
+	// goes live. Has no effect when Action is Scan.
+	// +kubebuilder:default:=false
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// ExecutionHistoryLimit, when set, makes k8s-cleaner create an
+	// ExecutionRecord for every run of this Cleaner, keeping at most this
+	// many. Older records are pruned. When not set, no ExecutionRecord is
+	// created.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ExecutionHistoryLimit *int32 `json:"executionHistoryLimit,omitempty"`
+
+	// LuaLimits bounds the resources each Lua script invocation (Evaluate,
+	// AggregatedSelection and Transform) may use. Fields not set fall back
+	// to the controller-wide defaults.
+	// +optional
+	LuaLimits *LuaLimits `json:"luaLimits,omitempty"`
+
+	// EvaluationConcurrency is how many resources of a ResourceSelector are
+	// evaluated concurrently, fetching their events and logs and running
+	// Evaluate. Matching resources are reported in the order they are listed
+	// irrespective of it. When not set, the controller-wide default is used.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	EvaluationConcurrency *int32 `json:"evaluationConcurrency,omitempty"`
+
+	// Trigger configures what, besides Schedule, causes resources to be
+	// evaluated.
+	// +optional
+	Trigger *Trigger `json:"trigger,omitempty"`
+}
+
+// ExecutionOptions paces the Action of a Cleaner. For a Cleaner with Actions,
+// they apply to all steps together.
+type ExecutionOptions struct {
+	// MaxActionsPerSecond caps how many resources the Action is taken on
+	// per second.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxActionsPerSecond *int32 `json:"maxActionsPerSecond,omitempty"`
+
+	// BatchSize is the number of resources the Action is taken on before
+	// pausing for BatchPause. Ignored if BatchPause is not set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	BatchSize *int32 `json:"batchSize,omitempty"`
+
+	// BatchPause is how long to wait between two batches.
+	// +optional
+	BatchPause *metav1.Duration `json:"batchPause,omitempty"`
+
+	// MaxActionsPerRun caps how many matching resources the Action is taken
+	// on in a single run. The others are deferred: they are reported as
+	// skipped and, if they still match, acted on first by the next run.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxActionsPerRun *int32 `json:"maxActionsPerRun,omitempty"`
+}
+
+// ActionStep is one step of a Cleaner Actions pipeline.
+type ActionStep struct {
+	// Name identifies the step. Must be unique within the Cleaner. Later
+	// steps find the outcome of this one, for each resource, under this name.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Action is the action this step takes on the resources it selects.
+	// +kubebuilder:default:=Delete
+	Action Action `json:"action,omitempty"`
+
+	// Evaluate contains a function "evaluate" in lua language, selecting
+	// which of the resources reaching this step the Action is taken on.
+	// Besides "obj", the function is passed a global table "steps" holding,
+	// by step name, the outcome of each earlier step for obj: a table with
+	// fields "action", "outcome" (one of processed, skipped, failed or
+	// filtered) and "message". The message returned by the function, if any,
+	// replaces the one of the resource for this and later steps.
+	// If not set, all resources reaching this step are selected.
+	// +optional
+	Evaluate string `json:"evaluate,omitempty"`
+
+	// DeleteOptions configures the step when Action is Delete.
+	// +optional
+	DeleteOptions *DeleteOptions `json:"deleteOptions,omitempty"`
+
+	// Transform contains the "transform" function used when Action is
+	// Transform.
+	// +optional
+	Transform string `json:"transform,omitempty"`
+
+	// TransformOptions configures the step when Action is Transform.
+	// +optional
+	TransformOptions *TransformOptions `json:"transformOptions,omitempty"`
+
+	// ScaleOptions configures the step when Action is Scale.
+	// +optional
+	ScaleOptions *ScaleOptions `json:"scaleOptions,omitempty"`
+
+	// EvictOptions configures the step when Action is Evict.
+	// +optional
+	EvictOptions *EvictOptions `json:"evictOptions,omitempty"`
+
+	// DrainOptions configures the step when Action is Drain.
+	// +optional
+	DrainOptions *DrainOptions `json:"drainOptions,omitempty"`
+
+	// LabelOptions configures the step when Action is Label.
+	// +optional
+	LabelOptions *LabelOptions `json:"labelOptions,omitempty"`
+
+	// WebhookOptions configures the step when Action is Webhook.
+	// +optional
+	WebhookOptions *WebhookOptions `json:"webhookOptions,omitempty"`
+}
+
+// Trigger configures additional ways a Cleaner evaluates resources.
+type Trigger struct {
+	// OnChange, when set, makes k8s-cleaner watch the resources selected by
+	// ResourceSelectors and evaluate each one as soon as it is created or
+	// updated, taking Action on it if it is a match. Scheduled runs keep
+	// running as usual.
+	// OnChange is ignored when AggregatedSelection is set, OccurrenceThreshold
+	// is greater than one or Execution.MaxActionsPerRun is set, as all need
+	// all resources to be evaluated together.
+	// +optional
+	OnChange *OnChangeTrigger `json:"onChange,omitempty"`
+}
+
+// OnChangeTrigger configures event-triggered evaluation.
+type OnChangeTrigger struct {
+	// Debounce is how long to wait after a resource changes before evaluating
+	// it. Further changes to the same resource within this window are
+	// coalesced into a single evaluation.
+	// +kubebuilder:default:="10s"
+	// +optional
+	Debounce *metav1.Duration `json:"debounce,omitempty"`
+
+	// MaxEvaluationsPerMinute caps how many changed resources are evaluated
+	// per minute. Changes beyond that wait for their turn.
+	// +kubebuilder:default:=60
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxEvaluationsPerMinute *int32 `json:"maxEvaluationsPerMinute,omitempty"`
+}
+
+// LuaLimits configures the sandbox Lua scripts run in.
+type LuaLimits struct {
+	// Timeout is the maximum time a single invocation of a Lua script may run
+	// (e.g. the evaluate function on one resource).
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// CallStackSize is the maximum depth of the Lua call stack.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	CallStackSize *int32 `json:"callStackSize,omitempty"`
+
+	// RegistryMaxSize is the maximum number of slots of the Lua registry
+	// (the data stack holding local variables, arguments and temporaries).
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RegistryMaxSize *int32 `json:"registryMaxSize,omitempty"`
+}
+
+// TransformOptions configures the requests sent for a Transform action.
+type TransformOptions struct {
+	// FieldManager is the name of the field manager recorded for patch and
+	// server-side apply requests.
+	// +kubebuilder:default:=k8s-cleaner
+	// +optional
+	FieldManager string `json:"fieldManager,omitempty"`
+
+	// Force, when set, lets server-side apply take ownership of fields
+	// currently owned by another field manager. When not set, such a
+	// conflict fails the resource and is reported instead.
+	// +kubebuilder:default:=false
+	// +optional
+	Force bool `json:"force,omitempty"`
 }
 
 // BlastRadiusLimit caps how many resources a single Cleaner run is allowed to
@@ -354,6 +796,94 @@ type RollbackOptions struct {
 	Storage RollbackStorage `json:"storage,omitempty"`
 }
 
+const (
+	// ConditionTypeReady indicates the Cleaner is valid and scheduled.
+	ConditionTypeReady = "Ready"
+
+	// ConditionTypeLastRunSucceeded indicates the most recent run completed
+	// without errors.
+	ConditionTypeLastRunSucceeded = "LastRunSucceeded"
+
+	// ConditionTypeBlastRadiusExceeded indicates the most recent run was
+	// aborted because BlastRadiusLimit was exceeded.
+	ConditionTypeBlastRadiusExceeded = "BlastRadiusExceeded"
+
+	// ConditionTypeNotificationFailed indicates at least one Notification
+	// could not be delivered during the most recent run.
+	ConditionTypeNotificationFailed = "NotificationFailed"
+)
+
+const (
+	// ReasonScheduled is used when the next run has been scheduled.
+	ReasonScheduled = "Scheduled"
+
+	// ReasonInvalidSchedule is used when Schedule cannot be parsed or too
+	// many start times were missed.
+	ReasonInvalidSchedule = "InvalidSchedule"
+
+	// ReasonInvalidScript is used when a Lua script does not compile.
+	ReasonInvalidScript = "InvalidScript"
+
+	// ReasonSucceeded is used when the most recent run completed without errors.
+	ReasonSucceeded = "Succeeded"
+
+	// ReasonFailed is used when the most recent run failed.
+	ReasonFailed = "Failed"
+
+	// ReasonLuaTimeout is used when the most recent run failed because a Lua
+	// script exceeded LuaLimits.Timeout.
+	ReasonLuaTimeout = "LuaTimeout"
+
+	// ReasonLuaCallStackOverflow is used when the most recent run failed
+	// because a Lua script exceeded LuaLimits.CallStackSize.
+	ReasonLuaCallStackOverflow = "LuaCallStackOverflow"
+
+	// ReasonLuaRegistryOverflow is used when the most recent run failed
+	// because a Lua script exceeded LuaLimits.RegistryMaxSize.
+	ReasonLuaRegistryOverflow = "LuaRegistryOverflow"
+
+	// ReasonWithinLimit is used when the most recent run did not exceed
+	// BlastRadiusLimit.
+	ReasonWithinLimit = "WithinLimit"
+
+	// ReasonLimitExceeded is used when the most recent run exceeded
+	// BlastRadiusLimit.
+	ReasonLimitExceeded = "LimitExceeded"
+
+	// ReasonNotificationsSent is used when every Notification was delivered.
+	ReasonNotificationsSent = "NotificationsSent"
+
+	// ReasonNotificationError is used when a Notification could not be delivered.
+	ReasonNotificationError = "NotificationError"
+)
+
+// RunStatistics summarizes the outcome of a Cleaner run.
+type RunStatistics struct {
+	// ScannedCount is the number of resources considered by the
+	// ResourceSelectors, before label/Lua filtering.
+	ScannedCount int `json:"scannedCount"`
+
+	// MatchedCount is the number of resources the Action was about to be
+	// taken on, after AggregatedSelection and OccurrenceThreshold.
+	MatchedCount int `json:"matchedCount"`
+
+	// ProcessedCount is the number of resources the Action was successfully
+	// taken on.
+	ProcessedCount int `json:"processedCount"`
+
+	// FailedCount is the number of resources the Action failed on.
+	FailedCount int `json:"failedCount"`
+
+	// SkippedCount is the number of matching resources the Action was not
+	// taken on because they are protected, deferred by MaxActionsPerRun or,
+	// for a two-phase Delete, still in their grace period or rescued.
+	// +optional
+	SkippedCount int `json:"skippedCount,omitempty"`
+
+	// Duration is how long the run took.
+	Duration metav1.Duration `json:"duration"`
+}
+
 // CleanerStatus defines the observed state of Cleaner
 type CleanerStatus struct {
 	// Information when next snapshot is scheduled
@@ -367,11 +897,34 @@ type CleanerStatus struct {
 	// FailureMessage provides more information about the error, if
 	// any occurred
 	FailureMessage *string `json:"failureMessage,omitempty"`
+
+	// LastRunStatistics summarizes the most recent completed run.
+	// +optional
+	LastRunStatistics *RunStatistics `json:"lastRunStatistics,omitempty"`
+
+	// Conditions represent the latest available observations of the
+	// Cleaner: Ready, LastRunSucceeded, BlastRadiusExceeded and
+	// NotificationFailed.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:resource:path=cleaners,scope=Cluster
 //+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Action",type="string",JSONPath=".spec.action"
+//+kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule",priority=1
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+//+kubebuilder:printcolumn:name="Succeeded",type="string",JSONPath=".status.conditions[?(@.type==\"LastRunSucceeded\")].status"
+//+kubebuilder:printcolumn:name="Matched",type="integer",JSONPath=".status.lastRunStatistics.matchedCount"
+//+kubebuilder:printcolumn:name="Processed",type="integer",JSONPath=".status.lastRunStatistics.processedCount"
+//+kubebuilder:printcolumn:name="Failed",type="integer",JSONPath=".status.lastRunStatistics.failedCount"
+//+kubebuilder:printcolumn:name="Duration",type="string",JSONPath=".status.lastRunStatistics.duration",priority=1
+//+kubebuilder:printcolumn:name="Last Run",type="date",JSONPath=".status.lastRunTime"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Cleaner is the Schema for the cleaners API
 type Cleaner struct {
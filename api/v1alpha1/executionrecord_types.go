@@ -0,0 +1,164 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// ExecutionRecordCleanerLabel is set on every ExecutionRecord to the name
+	// of the Cleaner whose run it describes.
+	ExecutionRecordCleanerLabel = "apps.projectsveltos.io/cleaner"
+)
+
+// ResourceOutcome is what happened to a single resource during a Cleaner run.
+// +kubebuilder:validation:Enum:=Processed;Failed;Skipped;Deferred
+type ResourceOutcome string
+
+const (
+	// ResourceOutcomeProcessed means the Cleaner's Action was successfully
+	// taken on the resource (or, for Scan, the resource was reported).
+	ResourceOutcomeProcessed = ResourceOutcome("Processed")
+
+	// ResourceOutcomeFailed means the Cleaner's Action failed on the resource.
+	ResourceOutcomeFailed = ResourceOutcome("Failed")
+
+	// ResourceOutcomeSkipped means the Action was not taken on the resource
+	// because it is protected or, for a two-phase Delete, still in its grace
+	// period or rescued.
+	ResourceOutcomeSkipped = ResourceOutcome("Skipped")
+
+	// ResourceOutcomeDeferred means the Action was not taken on the resource
+	// because the run reached ExecutionOptions.MaxActionsPerRun. It is acted
+	// on first by the next run.
+	ResourceOutcomeDeferred = ResourceOutcome("Deferred")
+)
+
+// ExecutionRecordResource is the outcome of a Cleaner run on a single resource.
+type ExecutionRecordResource struct {
+	// Resource identify a Kubernetes resource
+	Resource corev1.ObjectReference `json:"resource"`
+
+	// Outcome is what happened to the resource.
+	Outcome ResourceOutcome `json:"outcome"`
+
+	// Message is the message returned by the Evaluate script or, when
+	// Outcome is not Processed, why.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ExecutionRecordSpec describes a single Cleaner run.
+type ExecutionRecordSpec struct {
+	// CleanerName is the name of the Cleaner this run belongs to.
+	CleanerName string `json:"cleanerName"`
+
+	// StartTime is when the run started.
+	StartTime metav1.Time `json:"startTime"`
+
+	// EndTime is when the run completed.
+	EndTime metav1.Time `json:"endTime"`
+
+	// Action is the action the Cleaner was configured with.
+	Action Action `json:"action"`
+
+	// DryRun indicates the run was executed in DryRun mode.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// ScannedCount is the number of resources considered by the
+	// ResourceSelectors, before label/Lua filtering.
+	ScannedCount int `json:"scannedCount"`
+
+	// MatchedCount is the number of resources the Action was about to be
+	// taken on, after AggregatedSelection and OccurrenceThreshold.
+	MatchedCount int `json:"matchedCount"`
+
+	// ProcessedCount is the number of resources the Action was successfully
+	// taken on.
+	ProcessedCount int `json:"processedCount"`
+
+	// FailedCount is the number of resources the Action failed on.
+	FailedCount int `json:"failedCount"`
+
+	// SkippedCount is the number of matching resources the Action was not
+	// taken on because they are protected or, for a two-phase Delete, still
+	// in their grace period or rescued.
+	// +optional
+	SkippedCount int `json:"skippedCount,omitempty"`
+
+	// DeferredCount is the number of matching resources left for the next
+	// run because ExecutionOptions.MaxActionsPerRun was reached.
+	// +optional
+	DeferredCount int `json:"deferredCount,omitempty"`
+
+	// BlastRadiusExceeded indicates the run was aborted by BlastRadiusLimit.
+	// +optional
+	BlastRadiusExceeded bool `json:"blastRadiusExceeded,omitempty"`
+
+	// FailureMessage is the error the run ended with, if any.
+	// +optional
+	FailureMessage string `json:"failureMessage,omitempty"`
+
+	// Resources lists the outcome for each resource. It is capped, so it
+	// may be shorter than the sum of the counts above; see ResourcesTruncated.
+	// +optional
+	Resources []ExecutionRecordResource `json:"resources,omitempty"`
+
+	// ResourcesTruncated indicates Resources was capped.
+	// +optional
+	ResourcesTruncated bool `json:"resourcesTruncated,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:path=executionrecords,scope=Cluster
+//+kubebuilder:printcolumn:name="Cleaner",type="string",JSONPath=".spec.cleanerName"
+//+kubebuilder:printcolumn:name="Action",type="string",JSONPath=".spec.action"
+//+kubebuilder:printcolumn:name="Processed",type="integer",JSONPath=".spec.processedCount"
+//+kubebuilder:printcolumn:name="Failed",type="integer",JSONPath=".spec.failedCount"
+//+kubebuilder:printcolumn:name="Start",type="date",JSONPath=".spec.startTime"
+
+// ExecutionRecord is the Schema for the executionrecords API.
+// One instance is created, and never modified, per Cleaner run.
+type ExecutionRecord struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ExecutionRecordSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ExecutionRecordList contains a list of ExecutionRecord
+type ExecutionRecordList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ExecutionRecord `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(func(scheme *runtime.Scheme) error {
+		scheme.AddKnownTypes(GroupVersion,
+			&ExecutionRecord{},
+			&ExecutionRecordList{},
+		)
+		return nil
+	})
+}
@@ -36,6 +36,17 @@ type ResourceInfo struct {
 	// Message is an optional field.
 	// +optional
 	Message string `json:"message,omitempty"`
+
+	// Diff is the JSON merge patch between the resource as it was and as the
+	// API server would have persisted it. Only populated for a Transform action
+	// run in DryRun mode.
+	// +optional
+	Diff string `json:"diff,omitempty"`
+
+	// PreviousReplicas is the replica count of the resource before a Scale
+	// action changed it. It is used to roll the Scale action back.
+	// +optional
+	PreviousReplicas *int32 `json:"previousReplicas,omitempty"`
 }
 
 // ReportSpec defines the desired state of Report
@@ -45,6 +56,63 @@ type ReportSpec struct {
 
 	// Action indicates the action to take on selected object.
 	Action Action `json:"action"`
+
+	// DryRun indicates the execution ran in DryRun mode: resources listed
+	// here were not actually deleted or updated.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// SkippedCount is the number of matching resources the Action was not
+	// taken on because they are protected (for instance because they are in
+	// a namespace protected by the controller), for a two-phase Delete,
+	// still in their grace period or rescued or, for Evict and Drain, blocked
+	// by a PodDisruptionBudget. Resources deferred to a later run, because of
+	// Execution.MaxActionsPerRun, are also counted.
+	// +optional
+	SkippedCount int `json:"skippedCount,omitempty"`
+
+	// SkippedResources lists the matching resources the Action was not taken
+	// on. Message contains the reason.
+	// +optional
+	SkippedResources []ResourceInfo `json:"skippedResources,omitempty"`
+
+	// FailedCount is the number of matching resources the Action failed on.
+	// +optional
+	FailedCount int `json:"failedCount,omitempty"`
+
+	// FailedResources lists the matching resources the Action failed on.
+	// Message contains the error.
+	// +optional
+	FailedResources []ResourceInfo `json:"failedResources,omitempty"`
+
+	// Steps contains, for a Cleaner with Actions, the outcome of each step,
+	// in order. For such Cleaners, Action, ResourceInfo, SkippedResources and
+	// FailedResources above describe the last step only.
+	// +optional
+	Steps []StepReport `json:"steps,omitempty"`
+}
+
+// StepReport is the outcome of one step of a Cleaner Actions pipeline.
+type StepReport struct {
+	// Name is the name of the step.
+	Name string `json:"name"`
+
+	// Action is the action the step took.
+	Action Action `json:"action"`
+
+	// ResourceInfo lists the resources the step processed.
+	// +optional
+	ResourceInfo []ResourceInfo `json:"resourceInfo,omitempty"`
+
+	// SkippedResources lists the resources the step selected but did not
+	// take its Action on. Message contains the reason.
+	// +optional
+	SkippedResources []ResourceInfo `json:"skippedResources,omitempty"`
+
+	// FailedResources lists the resources the step failed on. Those are not
+	// passed to later steps. Message contains the error.
+	// +optional
+	FailedResources []ResourceInfo `json:"failedResources,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -27,6 +27,56 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionStep) DeepCopyInto(out *ActionStep) {
+	*out = *in
+	if in.DeleteOptions != nil {
+		in, out := &in.DeleteOptions, &out.DeleteOptions
+		*out = new(DeleteOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TransformOptions != nil {
+		in, out := &in.TransformOptions, &out.TransformOptions
+		*out = new(TransformOptions)
+		**out = **in
+	}
+	if in.ScaleOptions != nil {
+		in, out := &in.ScaleOptions, &out.ScaleOptions
+		*out = new(ScaleOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EvictOptions != nil {
+		in, out := &in.EvictOptions, &out.EvictOptions
+		*out = new(EvictOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DrainOptions != nil {
+		in, out := &in.DrainOptions, &out.DrainOptions
+		*out = new(DrainOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LabelOptions != nil {
+		in, out := &in.LabelOptions, &out.LabelOptions
+		*out = new(LabelOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WebhookOptions != nil {
+		in, out := &in.WebhookOptions, &out.WebhookOptions
+		*out = new(WebhookOptions)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionStep.
+func (in *ActionStep) DeepCopy() *ActionStep {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BlastRadiusLimit) DeepCopyInto(out *BlastRadiusLimit) {
 	*out = *in
@@ -120,6 +170,48 @@ func (in *CleanerSpec) DeepCopyInto(out *CleanerSpec) {
 		*out = new(DeleteOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TransformOptions != nil {
+		in, out := &in.TransformOptions, &out.TransformOptions
+		*out = new(TransformOptions)
+		**out = **in
+	}
+	if in.ScaleOptions != nil {
+		in, out := &in.ScaleOptions, &out.ScaleOptions
+		*out = new(ScaleOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EvictOptions != nil {
+		in, out := &in.EvictOptions, &out.EvictOptions
+		*out = new(EvictOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DrainOptions != nil {
+		in, out := &in.DrainOptions, &out.DrainOptions
+		*out = new(DrainOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LabelOptions != nil {
+		in, out := &in.LabelOptions, &out.LabelOptions
+		*out = new(LabelOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WebhookOptions != nil {
+		in, out := &in.WebhookOptions, &out.WebhookOptions
+		*out = new(WebhookOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]ActionStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Execution != nil {
+		in, out := &in.Execution, &out.Execution
+		*out = new(ExecutionOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.StartingDeadlineSeconds != nil {
 		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
 		*out = new(int64)
@@ -142,6 +234,26 @@ func (in *CleanerSpec) DeepCopyInto(out *CleanerSpec) {
 		*out = new(RollbackOptions)
 		**out = **in
 	}
+	if in.ExecutionHistoryLimit != nil {
+		in, out := &in.ExecutionHistoryLimit, &out.ExecutionHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LuaLimits != nil {
+		in, out := &in.LuaLimits, &out.LuaLimits
+		*out = new(LuaLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EvaluationConcurrency != nil {
+		in, out := &in.EvaluationConcurrency, &out.EvaluationConcurrency
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Trigger != nil {
+		in, out := &in.Trigger, &out.Trigger
+		*out = new(Trigger)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanerSpec.
@@ -170,6 +282,18 @@ func (in *CleanerStatus) DeepCopyInto(out *CleanerStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.LastRunStatistics != nil {
+		in, out := &in.LastRunStatistics, &out.LastRunStatistics
+		*out = new(RunStatistics)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanerStatus.
@@ -195,6 +319,11 @@ func (in *DeleteOptions) DeepCopyInto(out *DeleteOptions) {
 		*out = new(v1.DeletionPropagation)
 		**out = **in
 	}
+	if in.TwoPhase != nil {
+		in, out := &in.TwoPhase, &out.TwoPhase
+		*out = new(TwoPhaseDelete)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeleteOptions.
@@ -207,6 +336,221 @@ func (in *DeleteOptions) DeepCopy() *DeleteOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainOptions) DeepCopyInto(out *DrainOptions) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.GracePeriodSeconds != nil {
+		in, out := &in.GracePeriodSeconds, &out.GracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainOptions.
+func (in *DrainOptions) DeepCopy() *DrainOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvictOptions) DeepCopyInto(out *EvictOptions) {
+	*out = *in
+	if in.GracePeriodSeconds != nil {
+		in, out := &in.GracePeriodSeconds, &out.GracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvictOptions.
+func (in *EvictOptions) DeepCopy() *EvictOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(EvictOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecutionOptions) DeepCopyInto(out *ExecutionOptions) {
+	*out = *in
+	if in.MaxActionsPerSecond != nil {
+		in, out := &in.MaxActionsPerSecond, &out.MaxActionsPerSecond
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BatchSize != nil {
+		in, out := &in.BatchSize, &out.BatchSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BatchPause != nil {
+		in, out := &in.BatchPause, &out.BatchPause
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxActionsPerRun != nil {
+		in, out := &in.MaxActionsPerRun, &out.MaxActionsPerRun
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionOptions.
+func (in *ExecutionOptions) DeepCopy() *ExecutionOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecutionOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecutionRecord) DeepCopyInto(out *ExecutionRecord) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionRecord.
+func (in *ExecutionRecord) DeepCopy() *ExecutionRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecutionRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExecutionRecord) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecutionRecordList) DeepCopyInto(out *ExecutionRecordList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ExecutionRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionRecordList.
+func (in *ExecutionRecordList) DeepCopy() *ExecutionRecordList {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecutionRecordList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExecutionRecordList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecutionRecordResource) DeepCopyInto(out *ExecutionRecordResource) {
+	*out = *in
+	out.Resource = in.Resource
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionRecordResource.
+func (in *ExecutionRecordResource) DeepCopy() *ExecutionRecordResource {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecutionRecordResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecutionRecordSpec) DeepCopyInto(out *ExecutionRecordSpec) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.EndTime.DeepCopyInto(&out.EndTime)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ExecutionRecordResource, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionRecordSpec.
+func (in *ExecutionRecordSpec) DeepCopy() *ExecutionRecordSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecutionRecordSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LabelOptions) DeepCopyInto(out *LabelOptions) {
+	*out = *in
+	if in.AddLabels != nil {
+		in, out := &in.AddLabels, &out.AddLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RemoveLabels != nil {
+		in, out := &in.RemoveLabels, &out.RemoveLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AddAnnotations != nil {
+		in, out := &in.AddAnnotations, &out.AddAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RemoveAnnotations != nil {
+		in, out := &in.RemoveAnnotations, &out.RemoveAnnotations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LabelOptions.
+func (in *LabelOptions) DeepCopy() *LabelOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(LabelOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LogSource) DeepCopyInto(out *LogSource) {
 	*out = *in
@@ -232,6 +576,36 @@ func (in *LogSource) DeepCopy() *LogSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LuaLimits) DeepCopyInto(out *LuaLimits) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.CallStackSize != nil {
+		in, out := &in.CallStackSize, &out.CallStackSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RegistryMaxSize != nil {
+		in, out := &in.RegistryMaxSize, &out.RegistryMaxSize
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LuaLimits.
+func (in *LuaLimits) DeepCopy() *LuaLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(LuaLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricQuery) DeepCopyInto(out *MetricQuery) {
 	*out = *in
@@ -287,6 +661,51 @@ func (in *Notification) DeepCopy() *Notification {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnChangeTrigger) DeepCopyInto(out *OnChangeTrigger) {
+	*out = *in
+	if in.Debounce != nil {
+		in, out := &in.Debounce, &out.Debounce
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxEvaluationsPerMinute != nil {
+		in, out := &in.MaxEvaluationsPerMinute, &out.MaxEvaluationsPerMinute
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnChangeTrigger.
+func (in *OnChangeTrigger) DeepCopy() *OnChangeTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(OnChangeTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OwnerFilter) DeepCopyInto(out *OwnerFilter) {
+	*out = *in
+	if in.HasOwner != nil {
+		in, out := &in.HasOwner, &out.HasOwner
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OwnerFilter.
+func (in *OwnerFilter) DeepCopy() *OwnerFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(OwnerFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Report) DeepCopyInto(out *Report) {
 	*out = *in
@@ -355,6 +774,27 @@ func (in *ReportSpec) DeepCopyInto(out *ReportSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SkippedResources != nil {
+		in, out := &in.SkippedResources, &out.SkippedResources
+		*out = make([]ResourceInfo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FailedResources != nil {
+		in, out := &in.FailedResources, &out.FailedResources
+		*out = make([]ResourceInfo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]StepReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportSpec.
@@ -376,6 +816,11 @@ func (in *ResourceInfo) DeepCopyInto(out *ResourceInfo) {
 		*out = make([]byte, len(*in))
 		copy(*out, *in)
 	}
+	if in.PreviousReplicas != nil {
+		in, out := &in.PreviousReplicas, &out.PreviousReplicas
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceInfo.
@@ -413,11 +858,26 @@ func (in *ResourcePolicySet) DeepCopy() *ResourcePolicySet {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceSelector) DeepCopyInto(out *ResourceSelector) {
 	*out = *in
+	if in.ExcludeNamespaces != nil {
+		in, out := &in.ExcludeNamespaces, &out.ExcludeNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.LabelFilters != nil {
 		in, out := &in.LabelFilters, &out.LabelFilters
 		*out = make([]v1beta1.LabelFilter, len(*in))
 		copy(*out, *in)
 	}
+	if in.OwnerFilter != nil {
+		in, out := &in.OwnerFilter, &out.OwnerFilter
+		*out = new(OwnerFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OlderThan != nil {
+		in, out := &in.OlderThan, &out.OlderThan
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	if in.MetricSource != nil {
 		in, out := &in.MetricSource, &out.MetricSource
 		*out = new(MetricSource)
@@ -433,6 +893,11 @@ func (in *ResourceSelector) DeepCopyInto(out *ResourceSelector) {
 		*out = new(LogSource)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PageSize != nil {
+		in, out := &in.PageSize, &out.PageSize
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSelector.
@@ -459,3 +924,156 @@ func (in *RollbackOptions) DeepCopy() *RollbackOptions {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunStatistics) DeepCopyInto(out *RunStatistics) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunStatistics.
+func (in *RunStatistics) DeepCopy() *RunStatistics {
+	if in == nil {
+		return nil
+	}
+	out := new(RunStatistics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleOptions) DeepCopyInto(out *ScaleOptions) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleOptions.
+func (in *ScaleOptions) DeepCopy() *ScaleOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepReport) DeepCopyInto(out *StepReport) {
+	*out = *in
+	if in.ResourceInfo != nil {
+		in, out := &in.ResourceInfo, &out.ResourceInfo
+		*out = make([]ResourceInfo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SkippedResources != nil {
+		in, out := &in.SkippedResources, &out.SkippedResources
+		*out = make([]ResourceInfo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FailedResources != nil {
+		in, out := &in.FailedResources, &out.FailedResources
+		*out = make([]ResourceInfo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepReport.
+func (in *StepReport) DeepCopy() *StepReport {
+	if in == nil {
+		return nil
+	}
+	out := new(StepReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransformOptions) DeepCopyInto(out *TransformOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransformOptions.
+func (in *TransformOptions) DeepCopy() *TransformOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(TransformOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Trigger) DeepCopyInto(out *Trigger) {
+	*out = *in
+	if in.OnChange != nil {
+		in, out := &in.OnChange, &out.OnChange
+		*out = new(OnChangeTrigger)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Trigger.
+func (in *Trigger) DeepCopy() *Trigger {
+	if in == nil {
+		return nil
+	}
+	out := new(Trigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TwoPhaseDelete) DeepCopyInto(out *TwoPhaseDelete) {
+	*out = *in
+	out.GracePeriod = in.GracePeriod
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TwoPhaseDelete.
+func (in *TwoPhaseDelete) DeepCopy() *TwoPhaseDelete {
+	if in == nil {
+		return nil
+	}
+	out := new(TwoPhaseDelete)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookOptions) DeepCopyInto(out *WebhookOptions) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.SecretReference)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookOptions.
+func (in *WebhookOptions) DeepCopy() *WebhookOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookOptions)
+	in.DeepCopyInto(out)
+	return out
+}
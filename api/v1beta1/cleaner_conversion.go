@@ -0,0 +1,491 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"gianlucam76/k8s-cleaner/api/v1alpha1"
+)
+
+const (
+	// v1alpha1SpecAnnotation is set on a Cleaner converted from v1alpha1
+	// whose spec v1beta1 cannot represent, for instance options of an action
+	// other than the selected one, or a namespace selector that does not
+	// parse. It holds the v1alpha1 spec, restored when the Cleaner is
+	// converted back unless its spec was changed in the meantime.
+	v1alpha1SpecAnnotation = "apps.projectsveltos.io/v1alpha1-spec"
+
+	// v1beta1SpecAnnotation is the v1alpha1SpecAnnotation counterpart, set
+	// on a Cleaner converted to v1alpha1.
+	v1beta1SpecAnnotation = "apps.projectsveltos.io/v1beta1-spec"
+)
+
+// stashedSpec is the value of v1alpha1SpecAnnotation and v1beta1SpecAnnotation.
+type stashedSpec struct {
+	// Hash is the hash of the spec the Cleaner was converted to.
+	Hash string `json:"hash"`
+
+	// Spec is the spec the Cleaner was converted from.
+	Spec json.RawMessage `json:"spec"`
+}
+
+var _ conversion.Convertible = &Cleaner{}
+
+// ConvertTo converts this Cleaner to the Hub version (v1alpha1).
+func (src *Cleaner) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha1.Cleaner)
+	if !ok {
+		return fmt.Errorf("unexpected hub type %T", dstRaw)
+	}
+
+	in := src.DeepCopy()
+	dst.ObjectMeta = in.ObjectMeta
+	dst.Spec = convertSpecToHub(&in.Spec)
+	dst.Status = convertStatusToHub(&in.Status)
+
+	if err := restoreSpec(&dst.ObjectMeta, v1alpha1SpecAnnotation, &in.Spec, &dst.Spec); err != nil {
+		return err
+	}
+
+	if equality.Semantic.DeepEqual(convertSpecFromHub(&dst.Spec), in.Spec) {
+		return nil
+	}
+	return stashSpec(&dst.ObjectMeta, v1beta1SpecAnnotation, &in.Spec, &dst.Spec)
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version.
+func (dst *Cleaner) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha1.Cleaner)
+	if !ok {
+		return fmt.Errorf("unexpected hub type %T", srcRaw)
+	}
+
+	in := src.DeepCopy()
+	dst.ObjectMeta = in.ObjectMeta
+	dst.Spec = convertSpecFromHub(&in.Spec)
+	dst.Status = convertStatusFromHub(&in.Status)
+
+	if err := restoreSpec(&dst.ObjectMeta, v1beta1SpecAnnotation, &in.Spec, &dst.Spec); err != nil {
+		return err
+	}
+
+	if equality.Semantic.DeepEqual(convertSpecToHub(&dst.Spec), in.Spec) {
+		return nil
+	}
+	return stashSpec(&dst.ObjectMeta, v1alpha1SpecAnnotation, &in.Spec, &dst.Spec)
+}
+
+// stashSpec records spec, the spec a Cleaner is converted from, in annotation
+// key of the converted Cleaner, along with the hash of converted, its spec.
+func stashSpec(meta *metav1.ObjectMeta, key string, spec, converted any) error {
+	raw, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+	hash, err := specHash(converted)
+	if err != nil {
+		return err
+	}
+	value, err := json.Marshal(stashedSpec{Hash: hash, Spec: raw})
+	if err != nil {
+		return err
+	}
+
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[key] = string(value)
+	return nil
+}
+
+// restoreSpec removes annotation key from a Cleaner being converted. If the
+// spec it was converted from, current, is still the one stashed Cleaner was
+// converted to, the stashed spec is restored in restored.
+func restoreSpec[T any](meta *metav1.ObjectMeta, key string, current any, restored *T) error {
+	value, ok := meta.Annotations[key]
+	if !ok {
+		return nil
+	}
+	delete(meta.Annotations, key)
+	if len(meta.Annotations) == 0 {
+		meta.Annotations = nil
+	}
+
+	var stashed stashedSpec
+	if err := json.Unmarshal([]byte(value), &stashed); err != nil {
+		// Not set by a conversion: there is nothing to restore.
+		return nil
+	}
+	hash, err := specHash(current)
+	if err != nil {
+		return err
+	}
+	if hash != stashed.Hash {
+		return nil
+	}
+
+	var spec T
+	if err := json.Unmarshal(stashed.Spec, &spec); err != nil {
+		return nil
+	}
+	*restored = spec
+	return nil
+}
+
+func specHash(spec any) (string, error) {
+	raw, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func convertSpecToHub(in *CleanerSpec) v1alpha1.CleanerSpec {
+	out := v1alpha1.CleanerSpec{
+		ResourcePolicySet:       convertResourcePolicySetToHub(&in.ResourcePolicySet),
+		Execution:               (*v1alpha1.ExecutionOptions)(in.Execution),
+		Schedule:                in.Schedule,
+		StartingDeadlineSeconds: in.StartingDeadlineSeconds,
+		OccurrenceThreshold:     in.OccurrenceThreshold,
+		BlastRadiusLimit:        (*v1alpha1.BlastRadiusLimit)(in.BlastRadiusLimit),
+		DryRun:                  in.DryRun,
+		ExecutionHistoryLimit:   in.ExecutionHistoryLimit,
+		LuaLimits:               (*v1alpha1.LuaLimits)(in.LuaLimits),
+		EvaluationConcurrency:   in.EvaluationConcurrency,
+	}
+
+	var step v1alpha1.ActionStep
+	convertActionToHub(&in.Action, &step)
+	out.Action = step.Action
+	out.DeleteOptions = step.DeleteOptions
+	out.Transform = step.Transform
+	out.TransformOptions = step.TransformOptions
+	out.ScaleOptions = step.ScaleOptions
+	out.EvictOptions = step.EvictOptions
+	out.DrainOptions = step.DrainOptions
+	out.LabelOptions = step.LabelOptions
+	out.WebhookOptions = step.WebhookOptions
+
+	if in.Actions != nil {
+		out.Actions = make([]v1alpha1.ActionStep, len(in.Actions))
+		for i := range in.Actions {
+			out.Actions[i].Name = in.Actions[i].Name
+			out.Actions[i].Evaluate = in.Actions[i].Evaluate
+			convertActionToHub(&in.Actions[i].Action, &out.Actions[i])
+		}
+	}
+
+	if in.Notifications != nil {
+		out.Notifications = make([]v1alpha1.Notification, len(in.Notifications))
+		for i := range in.Notifications {
+			out.Notifications[i] = v1alpha1.Notification{
+				Name:            in.Notifications[i].Name,
+				Type:            v1alpha1.NotificationType(in.Notifications[i].Type),
+				NotificationRef: in.Notifications[i].NotificationRef,
+			}
+		}
+	}
+
+	if in.StoreResources != nil {
+		out.StoreResourcePath = in.StoreResources.Path
+	}
+
+	if in.Rollback != nil {
+		out.Rollback = &v1alpha1.RollbackOptions{Storage: v1alpha1.RollbackStorage(in.Rollback.Storage)}
+	}
+
+	if in.Trigger != nil {
+		out.Trigger = &v1alpha1.Trigger{OnChange: (*v1alpha1.OnChangeTrigger)(in.Trigger.OnChange)}
+	}
+
+	return out
+}
+
+func convertSpecFromHub(in *v1alpha1.CleanerSpec) CleanerSpec {
+	out := CleanerSpec{
+		ResourcePolicySet:       convertResourcePolicySetFromHub(&in.ResourcePolicySet),
+		Execution:               (*ExecutionOptions)(in.Execution),
+		Schedule:                in.Schedule,
+		StartingDeadlineSeconds: in.StartingDeadlineSeconds,
+		OccurrenceThreshold:     in.OccurrenceThreshold,
+		BlastRadiusLimit:        (*BlastRadiusLimit)(in.BlastRadiusLimit),
+		DryRun:                  in.DryRun,
+		ExecutionHistoryLimit:   in.ExecutionHistoryLimit,
+		LuaLimits:               (*LuaLimits)(in.LuaLimits),
+		EvaluationConcurrency:   in.EvaluationConcurrency,
+	}
+
+	out.Action = convertActionFromHub(&v1alpha1.ActionStep{
+		Action:           in.Action,
+		DeleteOptions:    in.DeleteOptions,
+		Transform:        in.Transform,
+		TransformOptions: in.TransformOptions,
+		ScaleOptions:     in.ScaleOptions,
+		EvictOptions:     in.EvictOptions,
+		DrainOptions:     in.DrainOptions,
+		LabelOptions:     in.LabelOptions,
+		WebhookOptions:   in.WebhookOptions,
+	})
+
+	if in.Actions != nil {
+		out.Actions = make([]ActionStep, len(in.Actions))
+		for i := range in.Actions {
+			out.Actions[i] = ActionStep{
+				Name:     in.Actions[i].Name,
+				Action:   convertActionFromHub(&in.Actions[i]),
+				Evaluate: in.Actions[i].Evaluate,
+			}
+		}
+	}
+
+	if in.Notifications != nil {
+		out.Notifications = make([]Notification, len(in.Notifications))
+		for i := range in.Notifications {
+			out.Notifications[i] = Notification{
+				Name:            in.Notifications[i].Name,
+				Type:            NotificationType(in.Notifications[i].Type),
+				NotificationRef: in.Notifications[i].NotificationRef,
+			}
+		}
+	}
+
+	if in.StoreResourcePath != "" {
+		out.StoreResources = &StoreResources{Path: in.StoreResourcePath}
+	}
+
+	if in.Rollback != nil {
+		out.Rollback = &RollbackOptions{Storage: RollbackStorage(in.Rollback.Storage)}
+	}
+
+	if in.Trigger != nil {
+		out.Trigger = &Trigger{OnChange: (*OnChangeTrigger)(in.Trigger.OnChange)}
+	}
+
+	return out
+}
+
+// convertActionToHub sets the action fields of out, a v1alpha1 step, from
+// in. The options of every action set in in are converted, so that nothing
+// is lost if in is not valid.
+func convertActionToHub(in *Action, out *v1alpha1.ActionStep) {
+	out.Action = v1alpha1.Action(in.Type)
+	out.DeleteOptions = convertDeleteOptionsToHub(in.Delete)
+	if in.Transform != nil {
+		out.Transform = in.Transform.Script
+		if in.Transform.FieldManager != "" || in.Transform.Force {
+			out.TransformOptions = &v1alpha1.TransformOptions{
+				FieldManager: in.Transform.FieldManager,
+				Force:        in.Transform.Force,
+			}
+		}
+	}
+	out.ScaleOptions = (*v1alpha1.ScaleOptions)(in.Scale)
+	out.EvictOptions = (*v1alpha1.EvictOptions)(in.Evict)
+	out.DrainOptions = (*v1alpha1.DrainOptions)(in.Drain)
+	out.LabelOptions = (*v1alpha1.LabelOptions)(in.Label)
+	out.WebhookOptions = (*v1alpha1.WebhookOptions)(in.Webhook)
+}
+
+// convertActionFromHub returns the action of in, a v1alpha1 step. Only the
+// options of the selected action are converted.
+func convertActionFromHub(in *v1alpha1.ActionStep) Action {
+	out := Action{Type: ActionType(in.Action)}
+
+	switch in.Action {
+	case v1alpha1.ActionDelete:
+		out.Delete = convertDeleteOptionsFromHub(in.DeleteOptions)
+	case v1alpha1.ActionTransform:
+		if in.Transform != "" || in.TransformOptions != nil {
+			out.Transform = &TransformAction{Script: in.Transform}
+			if in.TransformOptions != nil {
+				out.Transform.FieldManager = in.TransformOptions.FieldManager
+				out.Transform.Force = in.TransformOptions.Force
+			}
+		}
+	case v1alpha1.ActionScale:
+		out.Scale = (*ScaleOptions)(in.ScaleOptions)
+	case v1alpha1.ActionEvict:
+		out.Evict = (*EvictOptions)(in.EvictOptions)
+	case v1alpha1.ActionDrain:
+		out.Drain = (*DrainOptions)(in.DrainOptions)
+	case v1alpha1.ActionLabel:
+		out.Label = (*LabelOptions)(in.LabelOptions)
+	case v1alpha1.ActionWebhook:
+		out.Webhook = (*WebhookOptions)(in.WebhookOptions)
+	}
+
+	return out
+}
+
+func convertDeleteOptionsToHub(in *DeleteOptions) *v1alpha1.DeleteOptions {
+	if in == nil {
+		return nil
+	}
+	return &v1alpha1.DeleteOptions{
+		GracePeriodSeconds: in.GracePeriodSeconds,
+		PropagationPolicy:  in.PropagationPolicy,
+		TwoPhase:           (*v1alpha1.TwoPhaseDelete)(in.TwoPhase),
+	}
+}
+
+func convertDeleteOptionsFromHub(in *v1alpha1.DeleteOptions) *DeleteOptions {
+	if in == nil {
+		return nil
+	}
+	return &DeleteOptions{
+		GracePeriodSeconds: in.GracePeriodSeconds,
+		PropagationPolicy:  in.PropagationPolicy,
+		TwoPhase:           (*TwoPhaseDelete)(in.TwoPhase),
+	}
+}
+
+func convertResourcePolicySetToHub(in *ResourcePolicySet) v1alpha1.ResourcePolicySet {
+	out := v1alpha1.ResourcePolicySet{AggregatedSelection: in.AggregatedSelection}
+	if in.ResourceSelectors != nil {
+		out.ResourceSelectors = make([]v1alpha1.ResourceSelector, len(in.ResourceSelectors))
+		for i := range in.ResourceSelectors {
+			out.ResourceSelectors[i] = convertResourceSelectorToHub(&in.ResourceSelectors[i])
+		}
+	}
+	return out
+}
+
+func convertResourcePolicySetFromHub(in *v1alpha1.ResourcePolicySet) ResourcePolicySet {
+	out := ResourcePolicySet{AggregatedSelection: in.AggregatedSelection}
+	if in.ResourceSelectors != nil {
+		out.ResourceSelectors = make([]ResourceSelector, len(in.ResourceSelectors))
+		for i := range in.ResourceSelectors {
+			out.ResourceSelectors[i] = convertResourceSelectorFromHub(&in.ResourceSelectors[i])
+		}
+	}
+	return out
+}
+
+func convertResourceSelectorToHub(in *ResourceSelector) v1alpha1.ResourceSelector {
+	out := v1alpha1.ResourceSelector{
+		Namespace:                in.Namespace,
+		NamespaceSelector:        formatLabelSelector(in.NamespaceSelector),
+		ExcludeNamespaces:        in.ExcludeNamespaces,
+		ExcludeNamespaceSelector: formatLabelSelector(in.ExcludeNamespaceSelector),
+		Group:                    in.Group,
+		Version:                  in.Version,
+		Kind:                     in.Kind,
+		LabelFilters:             in.LabelFilters,
+		FieldSelector:            in.FieldSelector,
+		OwnerFilter:              (*v1alpha1.OwnerFilter)(in.OwnerFilter),
+		OlderThan:                in.OlderThan,
+		Evaluate:                 in.Evaluate,
+		ExcludeDeleted:           in.ExcludeDeleted,
+		MetricSource:             (*v1alpha1.MetricSource)(in.MetricSource),
+		IncludeEvents:            in.IncludeEvents,
+		LogSource:                (*v1alpha1.LogSource)(in.LogSource),
+		UseCache:                 in.UseCache,
+		PageSize:                 in.PageSize,
+	}
+	if in.MetricQueries != nil {
+		out.MetricQueries = make([]v1alpha1.MetricQuery, len(in.MetricQueries))
+		for i := range in.MetricQueries {
+			out.MetricQueries[i] = v1alpha1.MetricQuery(in.MetricQueries[i])
+		}
+	}
+	return out
+}
+
+func convertResourceSelectorFromHub(in *v1alpha1.ResourceSelector) ResourceSelector {
+	out := ResourceSelector{
+		Namespace:                in.Namespace,
+		NamespaceSelector:        parseLabelSelector(in.NamespaceSelector),
+		ExcludeNamespaces:        in.ExcludeNamespaces,
+		ExcludeNamespaceSelector: parseLabelSelector(in.ExcludeNamespaceSelector),
+		Group:                    in.Group,
+		Version:                  in.Version,
+		Kind:                     in.Kind,
+		LabelFilters:             in.LabelFilters,
+		FieldSelector:            in.FieldSelector,
+		OwnerFilter:              (*OwnerFilter)(in.OwnerFilter),
+		OlderThan:                in.OlderThan,
+		Evaluate:                 in.Evaluate,
+		ExcludeDeleted:           in.ExcludeDeleted,
+		MetricSource:             (*MetricSource)(in.MetricSource),
+		IncludeEvents:            in.IncludeEvents,
+		LogSource:                (*LogSource)(in.LogSource),
+		UseCache:                 in.UseCache,
+		PageSize:                 in.PageSize,
+	}
+	if in.MetricQueries != nil {
+		out.MetricQueries = make([]MetricQuery, len(in.MetricQueries))
+		for i := range in.MetricQueries {
+			out.MetricQueries[i] = MetricQuery(in.MetricQueries[i])
+		}
+	}
+	return out
+}
+
+// formatLabelSelector returns selector in the syntax of v1alpha1 namespace
+// selectors. A selector that is not valid is returned as "".
+func formatLabelSelector(selector *metav1.LabelSelector) string {
+	if selector == nil {
+		return ""
+	}
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return ""
+	}
+	return s.String()
+}
+
+// parseLabelSelector parses a v1alpha1 namespace selector. A selector that
+// does not parse is returned as nil.
+func parseLabelSelector(selector string) *metav1.LabelSelector {
+	if selector == "" {
+		return nil
+	}
+	s, err := metav1.ParseToLabelSelector(selector)
+	if err != nil {
+		return nil
+	}
+	return s
+}
+
+func convertStatusToHub(in *CleanerStatus) v1alpha1.CleanerStatus {
+	return v1alpha1.CleanerStatus{
+		NextScheduleTime:  in.NextScheduleTime,
+		LastRunTime:       in.LastRunTime,
+		FailureMessage:    in.FailureMessage,
+		LastRunStatistics: (*v1alpha1.RunStatistics)(in.LastRunStatistics),
+		Conditions:        in.Conditions,
+	}
+}
+
+func convertStatusFromHub(in *v1alpha1.CleanerStatus) CleanerStatus {
+	return CleanerStatus{
+		NextScheduleTime:  in.NextScheduleTime,
+		LastRunTime:       in.LastRunTime,
+		FailureMessage:    in.FailureMessage,
+		LastRunStatistics: (*RunStatistics)(in.LastRunStatistics),
+		Conditions:        in.Conditions,
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1_test
+
+import (
+	"math/rand"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metafuzzer "k8s.io/apimachinery/pkg/apis/meta/fuzzer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/randfill"
+
+	"gianlucam76/k8s-cleaner/api/v1alpha1"
+	"gianlucam76/k8s-cleaner/api/v1beta1"
+)
+
+const fuzzIterations = 1000
+
+func newFiller() *randfill.Filler {
+	scheme := runtime.NewScheme()
+	Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+	Expect(v1beta1.AddToScheme(scheme)).To(Succeed())
+
+	return fuzzer.FuzzerFor(metafuzzer.Funcs, rand.NewSource(GinkgoRandomSeed()), serializer.NewCodecFactory(scheme)).
+		NilChance(0.3).NumElements(0, 3)
+}
+
+var _ = Describe("Cleaner conversion", func() {
+	It("round-trips v1alpha1 Cleaners through v1beta1", func() {
+		filler := newFiller()
+		for range fuzzIterations {
+			hub := &v1alpha1.Cleaner{}
+			filler.Fill(hub)
+
+			spoke := &v1beta1.Cleaner{}
+			Expect(spoke.ConvertFrom(hub.DeepCopy())).To(Succeed())
+			restored := &v1alpha1.Cleaner{}
+			Expect(spoke.ConvertTo(restored)).To(Succeed())
+
+			Expect(equality.Semantic.DeepEqual(restored, hub)).To(BeTrue(),
+				"v1alpha1 Cleaner changed by a round-trip:\n%+v\n%+v", hub, restored)
+		}
+	})
+
+	It("round-trips v1beta1 Cleaners through v1alpha1", func() {
+		filler := newFiller()
+		for range fuzzIterations {
+			spoke := &v1beta1.Cleaner{}
+			filler.Fill(spoke)
+
+			hub := &v1alpha1.Cleaner{}
+			Expect(spoke.DeepCopy().ConvertTo(hub)).To(Succeed())
+			restored := &v1beta1.Cleaner{}
+			Expect(restored.ConvertFrom(hub)).To(Succeed())
+
+			Expect(equality.Semantic.DeepEqual(restored, spoke)).To(BeTrue(),
+				"v1beta1 Cleaner changed by a round-trip:\n%+v\n%+v", spoke, restored)
+		}
+	})
+
+	It("converts action options and namespace selectors", func() {
+		hub := &v1alpha1.Cleaner{
+			ObjectMeta: metav1.ObjectMeta{Name: "cleaner"},
+			Spec: v1alpha1.CleanerSpec{
+				Schedule: "0 * * * *",
+				Action:   v1alpha1.ActionScale,
+				ScaleOptions: &v1alpha1.ScaleOptions{
+					Replicas: ptr.To[int32](0),
+				},
+				ResourcePolicySet: v1alpha1.ResourcePolicySet{
+					ResourceSelectors: []v1alpha1.ResourceSelector{
+						{Kind: "Deployment", Group: "apps", Version: "v1", NamespaceSelector: "env in (dev,qa),team"},
+					},
+				},
+				StoreResourcePath: "/collection",
+			},
+		}
+
+		spoke := &v1beta1.Cleaner{}
+		Expect(spoke.ConvertFrom(hub)).To(Succeed())
+		Expect(spoke.Annotations).To(BeEmpty())
+		Expect(spoke.Spec.Action.Type).To(Equal(v1beta1.ActionScale))
+		Expect(spoke.Spec.Action.Scale).ToNot(BeNil())
+		Expect(spoke.Spec.Action.Scale.Replicas).To(Equal(ptr.To[int32](0)))
+		Expect(spoke.Spec.StoreResources).To(Equal(&v1beta1.StoreResources{Path: "/collection"}))
+
+		selector := spoke.Spec.ResourcePolicySet.ResourceSelectors[0].NamespaceSelector
+		Expect(selector).ToNot(BeNil())
+		Expect(selector.MatchExpressions).To(ConsistOf(
+			metav1.LabelSelectorRequirement{Key: "env", Operator: metav1.LabelSelectorOpIn, Values: []string{"dev", "qa"}},
+			metav1.LabelSelectorRequirement{Key: "team", Operator: metav1.LabelSelectorOpExists, Values: []string{}},
+		))
+	})
+
+	It("keeps what v1beta1 cannot represent in an annotation", func() {
+		hub := &v1alpha1.Cleaner{
+			ObjectMeta: metav1.ObjectMeta{Name: "cleaner"},
+			Spec: v1alpha1.CleanerSpec{
+				Schedule:     "0 * * * *",
+				Action:       v1alpha1.ActionDelete,
+				ScaleOptions: &v1alpha1.ScaleOptions{Replicas: ptr.To[int32](1)},
+			},
+		}
+
+		spoke := &v1beta1.Cleaner{}
+		Expect(spoke.ConvertFrom(hub.DeepCopy())).To(Succeed())
+		Expect(spoke.Spec.Action.Scale).To(BeNil())
+		Expect(spoke.Annotations).To(HaveKey("apps.projectsveltos.io/v1alpha1-spec"))
+
+		restored := &v1alpha1.Cleaner{}
+		Expect(spoke.DeepCopy().ConvertTo(restored)).To(Succeed())
+		Expect(restored.Annotations).To(BeEmpty())
+		Expect(restored.Spec.ScaleOptions).To(Equal(hub.Spec.ScaleOptions))
+
+		// Once the v1beta1 spec is changed, the stashed spec is stale.
+		spoke.Spec.Schedule = "30 * * * *"
+		Expect(spoke.ConvertTo(restored)).To(Succeed())
+		Expect(restored.Annotations).To(BeEmpty())
+		Expect(restored.Spec.Schedule).To(Equal("30 * * * *"))
+		Expect(restored.Spec.ScaleOptions).To(BeNil())
+	})
+})
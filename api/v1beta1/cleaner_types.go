@@ -0,0 +1,856 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+)
+
+// ActionType specifies the action to take on matching resources
+// +kubebuilder:validation:Enum:=Delete;Transform;Scan;Scale;Evict;Drain;Label;Webhook
+type ActionType string
+
+const (
+	// ActionDelete will delete the resource
+	ActionDelete = ActionType("Delete")
+
+	// ActionTransform will update object
+	ActionTransform = ActionType("Transform")
+
+	// ActionScan will identify matching objects. No action is taken on those.
+	ActionScan = ActionType("Scan")
+
+	// ActionScale will change the replica count of matching objects through
+	// their scale subresource.
+	ActionScale = ActionType("Scale")
+
+	// ActionEvict will evict matching Pods through the Eviction API, honoring
+	// PodDisruptionBudgets.
+	ActionEvict = ActionType("Evict")
+
+	// ActionDrain will cordon matching Nodes and evict their Pods, honoring
+	// PodDisruptionBudgets.
+	ActionDrain = ActionType("Drain")
+
+	// ActionLabel will add or remove labels and annotations of matching
+	// objects through a patch.
+	ActionLabel = ActionType("Label")
+
+	// ActionWebhook will POST each matching object to an external HTTP
+	// endpoint.
+	ActionWebhook = ActionType("Webhook")
+)
+
+// Action is the action taken on matching resources. Type selects the action,
+// and only the options of that action can be set.
+// +kubebuilder:validation:XValidation:rule="!has(self.delete) || self.type == 'Delete'",message="delete can only be set when type is Delete"
+// +kubebuilder:validation:XValidation:rule="has(self.transform) == (self.type == 'Transform')",message="transform must be set if, and only if, type is Transform"
+// +kubebuilder:validation:XValidation:rule="!has(self.scale) || self.type == 'Scale'",message="scale can only be set when type is Scale"
+// +kubebuilder:validation:XValidation:rule="!has(self.evict) || self.type == 'Evict'",message="evict can only be set when type is Evict"
+// +kubebuilder:validation:XValidation:rule="!has(self.drain) || self.type == 'Drain'",message="drain can only be set when type is Drain"
+// +kubebuilder:validation:XValidation:rule="!has(self.label) || self.type == 'Label'",message="label can only be set when type is Label"
+// +kubebuilder:validation:XValidation:rule="has(self.webhook) == (self.type == 'Webhook')",message="webhook must be set if, and only if, type is Webhook"
+// +union
+type Action struct {
+	// Type is the action to take. If set to Transform, the transform
+	// function is invoked and the object updated. If set to Scale, the
+	// object replica count is changed through its scale subresource. If set
+	// to Evict, Pods are evicted honoring PodDisruptionBudgets. If set to
+	// Drain, Nodes are cordoned and their Pods evicted. If set to Label,
+	// labels and annotations are added or removed through a patch. If set to
+	// Webhook, each object is POSTed to an external HTTP endpoint.
+	// +unionDiscriminator
+	// +kubebuilder:default:=Delete
+	Type ActionType `json:"type"`
+
+	// Delete configures the Delete action.
+	// +optional
+	Delete *DeleteOptions `json:"delete,omitempty"`
+
+	// Transform configures the Transform action.
+	// +optional
+	Transform *TransformAction `json:"transform,omitempty"`
+
+	// Scale configures the Scale action.
+	// +optional
+	Scale *ScaleOptions `json:"scale,omitempty"`
+
+	// Evict configures the Evict action.
+	// +optional
+	Evict *EvictOptions `json:"evict,omitempty"`
+
+	// Drain configures the Drain action.
+	// +optional
+	Drain *DrainOptions `json:"drain,omitempty"`
+
+	// Label configures the Label action.
+	// +optional
+	Label *LabelOptions `json:"label,omitempty"`
+
+	// Webhook configures the Webhook action.
+	// +optional
+	Webhook *WebhookOptions `json:"webhook,omitempty"`
+}
+
+// TransformAction configures the Transform action.
+type TransformAction struct {
+	// Script contains a function "transform" in lua language, invoked with
+	// each matching object.
+	// Must return a table with exactly one of the following fields:
+	// - "resource": the new object, sent with a full Update;
+	// - "jsonPatch": a list of RFC 6902 JSON patch operations;
+	// - "mergePatch": an RFC 7386 JSON merge patch;
+	// - "applyConfiguration": a partial object, sent with server-side apply.
+	// +kubebuilder:validation:MinLength=1
+	Script string `json:"script"`
+
+	// FieldManager is the name of the field manager recorded for patch and
+	// server-side apply requests.
+	// +kubebuilder:default:=k8s-cleaner
+	// +optional
+	FieldManager string `json:"fieldManager,omitempty"`
+
+	// Force, when set, lets server-side apply take ownership of fields
+	// currently owned by another field manager. When not set, such a
+	// conflict fails the resource and is reported instead.
+	// +kubebuilder:default:=false
+	// +optional
+	Force bool `json:"force,omitempty"`
+}
+
+// StoreResources configures where matching resources are stored.
+type StoreResources struct {
+	// Path is the directory full matching resources are dumped in. Must be
+	// on a volume mounted in the k8s-cleaner controller.
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
+}
+
+// DeleteOptions contains options for delete requests. It's generally a subset
+// of metav1.DeleteOptions.
+type DeleteOptions struct {
+	// GracePeriodSeconds is the duration in seconds before the object should be
+	// deleted. Value must be non-negative integer. The value zero indicates
+	// delete immediately. If this value is nil, the default grace period for the
+	// specified type will be used.
+	// +optional
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+
+	// PropagationPolicy determined whether and how garbage collection will be
+	// performed. Either this field or OrphanDependents may be set, but not both.
+	// The default policy is decided by the existing finalizer set in the
+	// metadata.finalizers and the resource-specific default policy.
+	// Acceptable values are: 'Orphan' - orphan the dependents; 'Background' -
+	// allow the garbage collector to delete the dependents in the background;
+	// 'Foreground' - a cascading policy that deletes all dependents in the
+	// foreground.
+	// +optional
+	PropagationPolicy *metav1.DeletionPropagation `json:"propagationPolicy,omitempty"`
+
+	// TwoPhase, when set, makes Delete two-phase. A matching resource is first
+	// annotated as scheduled for deletion and is only deleted by a later run,
+	// once GracePeriod has elapsed, if it still matches. Removing the
+	// annotation before then rescues the resource.
+	// +optional
+	TwoPhase *TwoPhaseDelete `json:"twoPhase,omitempty"`
+}
+
+// ScaleOptions configures the Scale action.
+type ScaleOptions struct {
+	// Replicas is the replica count matching resources are scaled to. The
+	// evaluate function can override it per resource by returning a
+	// "replicas" field. A resource with no replica count from either is
+	// reported as failed.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+}
+
+// EvictOptions configures the Evict action.
+type EvictOptions struct {
+	// GracePeriodSeconds is the duration in seconds evicted Pods are given to
+	// terminate. If nil, the Pod's own termination grace period is used.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+}
+
+// DrainOptions configures the Drain action.
+type DrainOptions struct {
+	// Timeout is how long a run waits for the Pods of a Node to be evicted.
+	// Pods still there afterwards, for instance because a PodDisruptionBudget
+	// blocks their eviction, are evicted by later runs while the Node stays
+	// cordoned. Zero means evictions are requested once, without waiting.
+	// Defaults to 5m.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// GracePeriodSeconds is the duration in seconds evicted Pods are given to
+	// terminate. If nil, each Pod's own termination grace period is used.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+
+	// DeleteNode, when set, deletes the Node once all its Pods are evicted.
+	// +optional
+	DeleteNode bool `json:"deleteNode,omitempty"`
+}
+
+// LabelOptions configures the Label action. Values of added labels and
+// annotations are Go templates, executed with the fields Message (the message
+// returned by the evaluate function), Name, Namespace and Kind of the
+// matching resource. Keys both added and removed are added.
+type LabelOptions struct {
+	// AddLabels are the labels set on matching resources.
+	// +optional
+	AddLabels map[string]string `json:"addLabels,omitempty"`
+
+	// RemoveLabels are the keys of the labels removed from matching resources.
+	// +optional
+	RemoveLabels []string `json:"removeLabels,omitempty"`
+
+	// AddAnnotations are the annotations set on matching resources.
+	// +optional
+	AddAnnotations map[string]string `json:"addAnnotations,omitempty"`
+
+	// RemoveAnnotations are the keys of the annotations removed from matching
+	// resources.
+	// +optional
+	RemoveAnnotations []string `json:"removeAnnotations,omitempty"`
+}
+
+// WebhookOptions configures the Webhook action.
+type WebhookOptions struct {
+	// URL is the HTTP(S) endpoint each matching resource is POSTed to, as
+	// JSON, along with the message returned by the evaluate function and the
+	// Cleaner metadata.
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+
+	// SecretRef optionally references a Secret containing credentials to
+	// authenticate against the endpoint.
+	// Supported keys: "token" (bearer), "username"+"password" (basic auth).
+	// +optional
+	SecretRef *corev1.SecretReference `json:"secretRef,omitempty"`
+
+	// Timeout is the timeout of each request. Defaults to 30s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// MaxRetries is how many times a request failing because of a network
+	// error, a 429 or a 5xx response is retried, with exponential backoff.
+	// Defaults to 3.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+}
+
+// TwoPhaseDelete configures a Delete that first marks resources and deletes
+// them only after a grace period.
+type TwoPhaseDelete struct {
+	// GracePeriod is how long a resource stays marked before it is deleted.
+	GracePeriod metav1.Duration `json:"gracePeriod"`
+}
+
+// MetricSource identifies a Prometheus-compatible metrics endpoint reachable
+// from within the cluster.
+type MetricSource struct {
+	// URL is the base HTTP(S) address of the Prometheus-compatible endpoint
+	// (e.g. http://prometheus.monitoring.svc:9090).
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+
+	// Path is the HTTP path for Prometheus instant queries.
+	// Defaults to api/v1/query when empty.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// SecretRef optionally references a Secret containing credentials to
+	// authenticate against the endpoint.
+	// Supported keys: "token" (bearer), "username"+"password" (basic auth).
+	// +optional
+	SecretRef *corev1.SecretReference `json:"secretRef,omitempty"`
+}
+
+// MetricQuery binds a PromQL instant-query result to a name the Evaluate
+// script can reference via the global metrics table (e.g. metrics["errorRate"]).
+type MetricQuery struct {
+	// Name is the key under which the scalar result is available in the script.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Query is a PromQL instant-query expression.
+	// +kubebuilder:validation:MinLength=1
+	Query string `json:"query"`
+}
+
+// LogSource configures fetching container log tails for a candidate resource
+// before it is evaluated. Only applies when the ResourceSelector targets Pods;
+// ignored (with a warning logged) for any other Kind.
+type LogSource struct {
+	// Containers restricts which containers' logs are fetched.
+	// Empty means every container in the Pod.
+	// +optional
+	Containers []string `json:"containers,omitempty"`
+
+	// TailLines is the number of most recent log lines fetched per container.
+	// +kubebuilder:default:=50
+	// +optional
+	TailLines *int64 `json:"tailLines,omitempty"`
+
+	// Previous additionally fetches, for every selected container that has
+	// restarted (RestartCount > 0), the log of its previous instance. Useful
+	// to inspect why a container in CrashLoopBackOff last exited.
+	// +kubebuilder:default:=false
+	// +optional
+	Previous bool `json:"previous,omitempty"`
+}
+
+// OwnerFilter selects resources based on their owner references.
+// All set fields must be satisfied.
+type OwnerFilter struct {
+	// HasOwner, if set, requires resources to have at least one owner
+	// reference (true) or none at all (false).
+	// +optional
+	HasOwner *bool `json:"hasOwner,omitempty"`
+
+	// Kind requires resources to have at least one owner of this Kind.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// OwnerMissing, when true, requires at least one owner of the resource
+	// (of Kind, if set) to no longer exist in the cluster.
+	// +optional
+	OwnerMissing bool `json:"ownerMissing,omitempty"`
+}
+
+type ResourceSelector struct {
+	// Namespace of the resource deployed in the  Cluster.
+	// Empty for resources scoped at cluster level.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// NamespaceSelector selects namespaces by label.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// ExcludeNamespaces lists namespaces whose resources are never selected,
+	// even if they match Namespace or NamespaceSelector.
+	// +optional
+	ExcludeNamespaces []string `json:"excludeNamespaces,omitempty"`
+
+	// ExcludeNamespaceSelector selects namespaces, by label, whose
+	// resources are never selected.
+	// +optional
+	ExcludeNamespaceSelector *metav1.LabelSelector `json:"excludeNamespaceSelector,omitempty"`
+
+	// Group of the resource deployed in the Cluster.
+	Group string `json:"group"`
+
+	// Version of the resource deployed in the Cluster.
+	Version string `json:"version"`
+
+	// Kind of the resource deployed in the Cluster.
+	// +kubebuilder:validation:MinLength=1
+	Kind string `json:"kind"`
+
+	// LabelFilters allows to filter resources based on current labels.
+	LabelFilters []libsveltosv1beta1.LabelFilter `json:"labelFilters,omitempty"`
+
+	// FieldSelector filters resources by field, using the same syntax as
+	// kubectl --field-selector (e.g. status.phase=Failed). Only fields
+	// supported by the API server for Kind can be used.
+	// +optional
+	FieldSelector string `json:"fieldSelector,omitempty"`
+
+	// OwnerFilter filters resources based on their owner references.
+	// +optional
+	OwnerFilter *OwnerFilter `json:"ownerFilter,omitempty"`
+
+	// OlderThan, if set, only selects resources created more than OlderThan ago.
+	// +optional
+	OlderThan *metav1.Duration `json:"olderThan,omitempty"`
+
+	// Evaluate contains a function "evaluate" in lua language.
+	// The function will be passed one of the object selected based on
+	// above criteria.
+	// Must return struct with field "matching" representing whether
+	// object is a match and an optional "message" field. When Action is
+	// Scale, an optional "replicas" field sets the replica count the object
+	// is scaled to.
+	// The global metrics table is available when MetricSource is set.
+	// The global events table is available when IncludeEvents is set.
+	// The global logs (and logsByContainer) table is available when LogSource is set.
+	// +optional
+	Evaluate string `json:"evaluate,omitempty"`
+
+	// ExcludeDeleted if set (default value), exclude resources marked as
+	// deleted. If set to false, k8s-cleaner will consider also resources marked as deleted.
+	// +kubebuilder:default:=true
+	ExcludeDeleted bool `json:"excludeDeleted,omitempty"`
+
+	// MetricSource identifies a Prometheus-compatible endpoint to query before
+	// evaluating each resource. Results are exposed to the Evaluate script via
+	// the global metrics table (e.g. metrics["myQuery"]).
+	// +optional
+	MetricSource *MetricSource `json:"metricSource,omitempty"`
+
+	// MetricQueries is a list of PromQL instant queries to execute against
+	// MetricSource. Each result is a scalar accessible in the Evaluate script
+	// via metrics["<name>"].
+	// +optional
+	MetricQueries []MetricQuery `json:"metricQueries,omitempty"`
+
+	// IncludeEvents, when true, fetches recent Events involving each candidate
+	// resource before it is evaluated. Results are exposed to the Evaluate
+	// script via the global events table: an array of
+	// {reason, message, type, count, lastTimestamp}.
+	// +kubebuilder:default:=false
+	// +optional
+	IncludeEvents bool `json:"includeEvents,omitempty"`
+
+	// LogSource configures fetching container log tails before evaluation.
+	// Applies only when this ResourceSelector's Kind is Pod. Results are exposed
+	// to the Evaluate script via the global logs table (every selected container's tail
+	// concatenated into one string) and logsByContainer table (container name -> tail
+	// string).
+	// +optional
+	LogSource *LogSource `json:"logSource,omitempty"`
+
+	// UseCache, when true, reads the resources selected by this ResourceSelector
+	// from an informer cache shared by all Cleaners instead of listing them from
+	// the API server on every run. The cache is started the first time a Cleaner
+	// needs it and stopped once no Cleaner uses it anymore. Resources read from
+	// the cache may be slightly stale.
+	// +kubebuilder:default:=false
+	// +optional
+	UseCache bool `json:"useCache,omitempty"`
+
+	// PageSize is the number of resources requested per List call. Resources
+	// are evaluated as each page is received and only matching ones are kept
+	// in memory. Defaults to 500. Ignored when resources are read from the cache.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PageSize *int64 `json:"pageSize,omitempty"`
+}
+
+type ResourcePolicySet struct {
+	// ResourceSelectors identifies what resources to select
+	ResourceSelectors []ResourceSelector `json:"resourceSelectors"`
+
+	// This field is optional and can be used to specify a Lua function
+	// that will be used to further select a subset of the resources that
+	// have already been selected using the ResourceSelector field.
+	// The function will receive the array of resources selected by ResourceSelectors.
+	// If this field is not specified, all resources selected by the ResourceSelector
+	// field will be considered.
+	// This field allows to perform more complex filtering or selection operations
+	// on the resources, looking at all resources together.
+	// This can be useful for more sophisticated tasks, such as identifying resources
+	// that are related to each other or that have similar properties.
+	AggregatedSelection string `json:"aggregatedSelection,omitempty"`
+}
+
+// NotificationType specifies different type of notifications
+// +kubebuilder:validation:Enum:=CleanerReport;Slack;Webex;Discord;Teams;SMTP;Telegram;Event
+type NotificationType string
+
+const (
+	// NotificationTypeCleanerReport refers to generating a CleanerReport instance
+	NotificationTypeCleanerReport = NotificationType("CleanerReport")
+
+	// NotificationTypeSlack refers to generating a Slack message
+	NotificationTypeSlack = NotificationType("Slack")
+
+	// NotificationTypeWebex refers to generating a Webex message
+	NotificationTypeWebex = NotificationType("Webex")
+
+	// NotificationTypeDiscord refers to generating a Discord message
+	NotificationTypeDiscord = NotificationType("Discord")
+
+	// NotificationTypeTeams refers to generating a Teams message
+	NotificationTypeTeams = NotificationType("Teams")
+
+	// NotificationTypeSMTP refers to sending an email
+	NotificationTypeSMTP = NotificationType("SMTP")
+
+	// NotificationTypeTelegram refers to sending a Telegram message
+	NotificationTypeTelegram = NotificationType("Telegram")
+
+	// NotificationTypeEvent refers to generating a Kubernetes event
+	NotificationTypeEvent = NotificationType("Event")
+)
+
+type Notification struct {
+	// Name of the notification check.
+	// Must be a DNS_LABEL and unique within the Cleaner.
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+
+	// NotificationType specifies the type of notification
+	Type NotificationType `json:"type"`
+
+	// NotificationRef is a reference to a notification-specific resource that holds
+	// the details for the notification.
+	// +optional
+	NotificationRef *corev1.ObjectReference `json:"notificationRef,omitempty"`
+}
+
+// CleanerSpec defines the desired state of Cleaner
+type CleanerSpec struct {
+	// ResourcePolicySet identifies a group of resources
+	ResourcePolicySet ResourcePolicySet `json:"resourcePolicySet"`
+
+	// Action is the action to take on selected objects. Default action is
+	// to delete them.
+	// +kubebuilder:default:={type: Delete}
+	// +optional
+	Action Action `json:"action"`
+
+	// Actions, when set, is an ordered list of steps taken on matching
+	// resources, each with its own optional evaluate function and options.
+	// A step only sees the resources earlier steps processed, skipped or did
+	// not select, and can look at their outcome. When set, Action is
+	// ignored. Rollback is not supported for
+	// Cleaners with Actions.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Actions []ActionStep `json:"actions,omitempty"`
+
+	// Execution paces the Action, so that acting on many resources does not
+	// put pressure on the API server.
+	// +optional
+	Execution *ExecutionOptions `json:"execution,omitempty"`
+
+	// Schedule in Cron format, see https://en.wikipedia.org/wiki/Cron.
+	Schedule string `json:"schedule"`
+
+	// Optional deadline in seconds for starting the job if it misses scheduled
+	// time for any reason.  Missed jobs executions will be counted as failed ones.
+	// +optional
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+
+	// Notification is a list of source of events to evaluate.
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Notifications []Notification `json:"notifications,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+
+	// StoreResources, when set, stores full matching resources.
+	// +optional
+	StoreResources *StoreResources `json:"storeResources,omitempty"`
+
+	// OccurrenceThreshold specifies how many consecutive times a resource must
+	// be identified as a match before the Action is taken or Notifications are sent.
+	// k8s-cleaner tracks these occurrences in an internal registry to ensure
+	// counters are reset if a resource becomes healthy between scans.
+	// +kubebuilder:default:=1
+	// +optional
+	OccurrenceThreshold int `json:"occurrenceThreshold,omitempty"`
+
+	// BlastRadiusLimit, when set, aborts Delete/Transform actions if the number of
+	// matching resources exceeds the configured limit. This does not apply when
+	// Action is Scan. Matching resources are still reported via Notifications and
+	// StoreResources so the run can be inspected.
+	// +optional
+	BlastRadiusLimit *BlastRadiusLimit `json:"blastRadiusLimit,omitempty"`
+
+	// Rollback, when set, captures the pre-action state of resources affected by
+	// a Delete, Transform or Label action, so the most recent execution can be
+	// reverted.
+	// Capturing this state requires a CleanerReport Notification to also be
+	// configured, since captured resources are persisted on the Report instance.
+	// +optional
+	Rollback *RollbackOptions `json:"rollback,omitempty"`
+
+	// DryRun, when set, runs the full pipeline (selection, AggregatedSelection,
+	// OccurrenceThreshold, BlastRadiusLimit and, for Transform, the transform
+	// function) but sends Delete and Update requests to the API server in
+	// server-side dry-run mode. Requests are validated and admitted as usual,
+	// but nothing is persisted. Outcomes, and for Transform the would-be diff,
+	// are reported via Notifications so a Cleaner can be reviewed before it
+	// goes live. Has no effect when Action is Scan.
+	// +kubebuilder:default:=false
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// ExecutionHistoryLimit, when set, makes k8s-cleaner create an
+	// ExecutionRecord for every run of this Cleaner, keeping at most this
+	// many. Older records are pruned. When not set, no ExecutionRecord is
+	// created.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ExecutionHistoryLimit *int32 `json:"executionHistoryLimit,omitempty"`
+
+	// LuaLimits bounds the resources each Lua script invocation (Evaluate,
+	// AggregatedSelection and Transform) may use. Fields not set fall back
+	// to the controller-wide defaults.
+	// +optional
+	LuaLimits *LuaLimits `json:"luaLimits,omitempty"`
+
+	// EvaluationConcurrency is how many resources of a ResourceSelector are
+	// evaluated concurrently, fetching their events and logs and running
+	// Evaluate. Matching resources are reported in the order they are listed
+	// irrespective of it. When not set, the controller-wide default is used.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	EvaluationConcurrency *int32 `json:"evaluationConcurrency,omitempty"`
+
+	// Trigger configures what, besides Schedule, causes resources to be
+	// evaluated.
+	// +optional
+	Trigger *Trigger `json:"trigger,omitempty"`
+}
+
+// ExecutionOptions paces the Action of a Cleaner. For a Cleaner with Actions,
+// they apply to all steps together.
+type ExecutionOptions struct {
+	// MaxActionsPerSecond caps how many resources the Action is taken on
+	// per second.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxActionsPerSecond *int32 `json:"maxActionsPerSecond,omitempty"`
+
+	// BatchSize is the number of resources the Action is taken on before
+	// pausing for BatchPause. Ignored if BatchPause is not set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	BatchSize *int32 `json:"batchSize,omitempty"`
+
+	// BatchPause is how long to wait between two batches.
+	// +optional
+	BatchPause *metav1.Duration `json:"batchPause,omitempty"`
+
+	// MaxActionsPerRun caps how many matching resources the Action is taken
+	// on in a single run. The others are deferred: they are reported as
+	// skipped and, if they still match, acted on first by the next run.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxActionsPerRun *int32 `json:"maxActionsPerRun,omitempty"`
+}
+
+// ActionStep is one step of a Cleaner Actions pipeline.
+type ActionStep struct {
+	// Name identifies the step. Must be unique within the Cleaner. Later
+	// steps find the outcome of this one, for each resource, under this name.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Action is the action this step takes on the resources it selects.
+	// +kubebuilder:default:={type: Delete}
+	// +optional
+	Action Action `json:"action"`
+
+	// Evaluate contains a function "evaluate" in lua language, selecting
+	// which of the resources reaching this step the Action is taken on.
+	// Besides "obj", the function is passed a global table "steps" holding,
+	// by step name, the outcome of each earlier step for obj: a table with
+	// fields "action", "outcome" (one of processed, skipped, failed or
+	// filtered) and "message". The message returned by the function, if any,
+	// replaces the one of the resource for this and later steps.
+	// If not set, all resources reaching this step are selected.
+	// +optional
+	Evaluate string `json:"evaluate,omitempty"`
+}
+
+// Trigger configures additional ways a Cleaner evaluates resources.
+type Trigger struct {
+	// OnChange, when set, makes k8s-cleaner watch the resources selected by
+	// ResourceSelectors and evaluate each one as soon as it is created or
+	// updated, taking Action on it if it is a match. Scheduled runs keep
+	// running as usual.
+	// OnChange is ignored when AggregatedSelection is set, OccurrenceThreshold
+	// is greater than one or Execution.MaxActionsPerRun is set, as all need
+	// all resources to be evaluated together.
+	// +optional
+	OnChange *OnChangeTrigger `json:"onChange,omitempty"`
+}
+
+// OnChangeTrigger configures event-triggered evaluation.
+type OnChangeTrigger struct {
+	// Debounce is how long to wait after a resource changes before evaluating
+	// it. Further changes to the same resource within this window are
+	// coalesced into a single evaluation.
+	// +kubebuilder:default:="10s"
+	// +optional
+	Debounce *metav1.Duration `json:"debounce,omitempty"`
+
+	// MaxEvaluationsPerMinute caps how many changed resources are evaluated
+	// per minute. Changes beyond that wait for their turn.
+	// +kubebuilder:default:=60
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxEvaluationsPerMinute *int32 `json:"maxEvaluationsPerMinute,omitempty"`
+}
+
+// LuaLimits configures the sandbox Lua scripts run in.
+type LuaLimits struct {
+	// Timeout is the maximum time a single invocation of a Lua script may run
+	// (e.g. the evaluate function on one resource).
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// CallStackSize is the maximum depth of the Lua call stack.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	CallStackSize *int32 `json:"callStackSize,omitempty"`
+
+	// RegistryMaxSize is the maximum number of slots of the Lua registry
+	// (the data stack holding local variables, arguments and temporaries).
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RegistryMaxSize *int32 `json:"registryMaxSize,omitempty"`
+}
+
+// BlastRadiusLimit caps how many resources a single Cleaner run is allowed to
+// affect. If both MaxCount and MaxPercentage are set, exceeding either aborts
+// the run.
+type BlastRadiusLimit struct {
+	// MaxCount aborts the run if more than this many resources match.
+	// +optional
+	MaxCount *int `json:"maxCount,omitempty"`
+
+	// MaxPercentage aborts the run if the matching resources are more than this
+	// percentage of all resources considered by the ResourceSelectors (i.e., before
+	// label/Lua filtering narrows them down).
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxPercentage *int `json:"maxPercentage,omitempty"`
+}
+
+// RollbackStorage specifies where Cleaner persists the pre-action state of a
+// resource so it can later be rolled back.
+// +kubebuilder:validation:Enum:=Report
+type RollbackStorage string
+
+const (
+	// RollbackStorageReport stores the pre-action resource inline in the
+	// Report instance, in ResourceInfo.FullResource.
+	RollbackStorageReport = RollbackStorage("Report")
+)
+
+// RollbackOptions configures rollback capture for a Cleaner.
+type RollbackOptions struct {
+	// Storage indicates where captured resources are persisted for rollback.
+	// +kubebuilder:default:=Report
+	// +optional
+	Storage RollbackStorage `json:"storage,omitempty"`
+}
+
+// RunStatistics summarizes the outcome of a Cleaner run.
+type RunStatistics struct {
+	// ScannedCount is the number of resources considered by the
+	// ResourceSelectors, before label/Lua filtering.
+	ScannedCount int `json:"scannedCount"`
+
+	// MatchedCount is the number of resources the Action was about to be
+	// taken on, after AggregatedSelection and OccurrenceThreshold.
+	MatchedCount int `json:"matchedCount"`
+
+	// ProcessedCount is the number of resources the Action was successfully
+	// taken on.
+	ProcessedCount int `json:"processedCount"`
+
+	// FailedCount is the number of resources the Action failed on.
+	FailedCount int `json:"failedCount"`
+
+	// SkippedCount is the number of matching resources the Action was not
+	// taken on because they are protected, deferred by MaxActionsPerRun or,
+	// for a two-phase Delete, still in their grace period or rescued.
+	// +optional
+	SkippedCount int `json:"skippedCount,omitempty"`
+
+	// Duration is how long the run took.
+	Duration metav1.Duration `json:"duration"`
+}
+
+// CleanerStatus defines the observed state of Cleaner
+type CleanerStatus struct {
+	// Information when next snapshot is scheduled
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// Information when was the last time a snapshot was successfully scheduled.
+	// +optional
+	LastRunTime *metav1.Time `json:"lastRunTime,omitempty"`
+
+	// FailureMessage provides more information about the error, if
+	// any occurred
+	FailureMessage *string `json:"failureMessage,omitempty"`
+
+	// LastRunStatistics summarizes the most recent completed run.
+	// +optional
+	LastRunStatistics *RunStatistics `json:"lastRunStatistics,omitempty"`
+
+	// Conditions represent the latest available observations of the
+	// Cleaner: Ready, LastRunSucceeded, BlastRadiusExceeded and
+	// NotificationFailed.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:path=cleaners,scope=Cluster
+//+kubebuilder:subresource:status
+//+kubebuilder:unservedversion
+//+kubebuilder:printcolumn:name="Action",type="string",JSONPath=".spec.action.type"
+//+kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule",priority=1
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+//+kubebuilder:printcolumn:name="Succeeded",type="string",JSONPath=".status.conditions[?(@.type==\"LastRunSucceeded\")].status"
+//+kubebuilder:printcolumn:name="Matched",type="integer",JSONPath=".status.lastRunStatistics.matchedCount"
+//+kubebuilder:printcolumn:name="Processed",type="integer",JSONPath=".status.lastRunStatistics.processedCount"
+//+kubebuilder:printcolumn:name="Failed",type="integer",JSONPath=".status.lastRunStatistics.failedCount"
+//+kubebuilder:printcolumn:name="Duration",type="string",JSONPath=".status.lastRunStatistics.duration",priority=1
+//+kubebuilder:printcolumn:name="Last Run",type="date",JSONPath=".status.lastRunTime"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Cleaner is the Schema for the cleaners API
+type Cleaner struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CleanerSpec   `json:"spec,omitempty"`
+	Status CleanerStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CleanerList contains a list of Cleaner
+type CleanerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Cleaner `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(func(scheme *runtime.Scheme) error {
+		scheme.AddKnownTypes(GroupVersion,
+			&Cleaner{},
+			&CleanerList{},
+		)
+		return nil
+	})
+}
@@ -44,9 +44,12 @@ import (
 	"github.com/projectsveltos/libsveltos/lib/logsettings"
 
 	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+	appsv1beta1 "gianlucam76/k8s-cleaner/api/v1beta1"
 	"gianlucam76/k8s-cleaner/internal/controller"
+	"gianlucam76/k8s-cleaner/internal/controller/executor"
 	"gianlucam76/k8s-cleaner/internal/telemetry"
 	internalweb "gianlucam76/k8s-cleaner/internal/web"
+	webhookv1alpha1 "gianlucam76/k8s-cleaner/internal/webhook/v1alpha1"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -61,12 +64,20 @@ var (
 	restConfigQPS         float32
 	restConfigBurst       int
 	webhookPort           int
+	enableWebhooks        bool
 	concurrentReconciles  int
 	syncPeriod            time.Duration
 	jitterWindowInSeconds int
 	healthAddr            string
 	disableTelemetry      bool
 	version               string
+	luaTimeout            time.Duration
+	luaCallStackSize      int
+	luaRegistryMaxSize    int
+	evaluationConcurrency int
+
+	protectedNamespaces         []string
+	protectedNamespaceSelectors []string
 )
 
 // Add RBAC for the authorized diagnostics endpoint.
@@ -129,6 +140,13 @@ func main() {
 		os.Exit(1)
 	}
 
+	executor.SetLuaDefaults(luaTimeout, luaCallStackSize, luaRegistryMaxSize)
+	executor.SetEvaluationConcurrency(evaluationConcurrency)
+	if err = executor.SetProtectedNamespaces(protectedNamespaces, protectedNamespaceSelectors); err != nil {
+		setupLog.Error(err, "invalid protected namespaces")
+		os.Exit(1)
+	}
+
 	if err = (&controller.CleanerReconciler{
 		Client:                mgr.GetClient(),
 		Scheme:                mgr.GetScheme(),
@@ -138,6 +156,13 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "Cleaner")
 		os.Exit(1)
 	}
+
+	if enableWebhooks {
+		if err = webhookv1alpha1.SetupCleanerWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Cleaner")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -213,6 +238,9 @@ func initFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&webhookPort, "webhook-port", defaultWebhookPort,
 		"Webhook Server port")
 
+	fs.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"Serve the validating and conversion webhooks for Cleaner. Requires a serving certificate, see config/certmanager")
+
 	const defaultSyncPeriod = 10
 	fs.DurationVar(&syncPeriod, "sync-period", defaultSyncPeriod*time.Minute,
 		fmt.Sprintf("The minimum interval at which watched resources are reconciled (e.g. 15m). Default: %d minutes",
@@ -230,6 +258,29 @@ func initFlags(fs *pflag.FlagSet) {
 
 	fs.BoolVar(&webReadOnly, "web-read-only", false,
 		"Disable scan triggers from the web UI")
+
+	const defaultLuaTimeout = 30
+	fs.DurationVar(&luaTimeout, "lua-timeout", defaultLuaTimeout*time.Second,
+		"Maximum time a single Lua script invocation can run. Cleaners can override it with spec.luaLimits.timeout")
+
+	const defaultLuaCallStackSize = 256
+	fs.IntVar(&luaCallStackSize, "lua-call-stack-size", defaultLuaCallStackSize,
+		"Maximum Lua call stack depth. Cleaners can override it with spec.luaLimits.callStackSize")
+
+	const defaultLuaRegistryMaxSize = 256 * 1024
+	fs.IntVar(&luaRegistryMaxSize, "lua-registry-max-size", defaultLuaRegistryMaxSize,
+		"Maximum number of Lua registry slots. Cleaners can override it with spec.luaLimits.registryMaxSize")
+
+	const defaultEvaluationConcurrency = 4
+	fs.IntVar(&evaluationConcurrency, "evaluation-concurrency", defaultEvaluationConcurrency,
+		"Number of resources of a ResourceSelector evaluated concurrently. Cleaners can override it with spec.evaluationConcurrency")
+
+	fs.StringSliceVar(&protectedNamespaces, "protected-namespaces", nil,
+		"Comma separated list of namespaces whose resources no Cleaner takes any Action but Scan on")
+
+	fs.StringArrayVar(&protectedNamespaceSelectors, "protected-namespace-selector", nil,
+		"Label selector for namespaces whose resources no Cleaner takes any Action but Scan on. "+
+			"Can be repeated")
 }
 
 //+kubebuilder:rbac:groups=*,resources=*,verbs=get;list;watch;delete
@@ -242,6 +293,9 @@ func initScheme() (*runtime.Scheme, error) {
 	if err := appsv1alpha1.AddToScheme(s); err != nil {
 		return nil, err
 	}
+	if err := appsv1beta1.AddToScheme(s); err != nil {
+		return nil, err
+	}
 
 	return s, nil
 }
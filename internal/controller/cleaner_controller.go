@@ -41,6 +41,13 @@ import (
 	"github.com/go-logr/logr"
 )
 
+const (
+	// runResultPollInterval is how often a Cleaner is reconciled while one of
+	// its runs is queued or in progress, so its status reflects the result
+	// soon after the run completes.
+	runResultPollInterval = 10 * time.Second
+)
+
 // CleanerReconciler reconciles a Cleaner object
 type CleanerReconciler struct {
 	client.Client
@@ -123,6 +130,15 @@ func (r *CleanerReconciler) reconcileDelete(ctx context.Context,
 		return err
 	}
 
+	err = executor.DeleteExecutionRecords(ctx, cleanerScope.Cleaner)
+	if err != nil {
+		return err
+	}
+
+	executor.StopWatchingChanges(cleanerScope.Cleaner.Name, logger)
+	executor.ReleaseInformers(cleanerScope.Cleaner.Name, logger)
+	executor.ForgetLuaScripts(cleanerScope.Cleaner.Name)
+
 	if controllerutil.ContainsFinalizer(cleanerScope.Cleaner, appsv1alpha1.CleanerFinalizer) {
 		controllerutil.RemoveFinalizer(cleanerScope.Cleaner, appsv1alpha1.CleanerFinalizer)
 	}
@@ -163,6 +179,22 @@ func (r *CleanerReconciler) reconcileNormal(ctx context.Context, cleanerScope *s
 			cleanerScope.SetFailureMessage(nil)
 		}
 	}
+	if result.ResultStatus == executor.Processed || result.ResultStatus == executor.Failed {
+		setRunStatus(cleanerScope, &result)
+	}
+
+	// Scripts are compiled once per generation. A script that does not compile
+	// will not until the Cleaner is updated, so there is no point in retrying.
+	if err := executor.LoadLuaScripts(cleanerScope.Cleaner); err != nil {
+		logger.Info(fmt.Sprintf("invalid Lua script: %v", err))
+		msg := err.Error()
+		cleanerScope.SetFailureMessage(&msg)
+		cleanerScope.SetCondition(appsv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			appsv1alpha1.ReasonInvalidScript, msg)
+		return ctrl.Result{}, nil
+	}
+
+	previousLastRunTime := cleanerScope.Cleaner.Status.LastRunTime
 
 	now := time.Now()
 	nextRun, err := schedule(ctx, cleanerScope, r.JitterWindowInSeconds, logger)
@@ -170,14 +202,74 @@ func (r *CleanerReconciler) reconcileNormal(ctx context.Context, cleanerScope *s
 		logger.Info("failed to get next run. Err: %v", err)
 		msg := err.Error()
 		cleanerScope.SetFailureMessage(&msg)
+		cleanerScope.SetCondition(appsv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			appsv1alpha1.ReasonInvalidSchedule, msg)
 		return ctrl.Result{}, err
 	}
+	cleanerScope.SetCondition(appsv1alpha1.ConditionTypeReady, metav1.ConditionTrue,
+		appsv1alpha1.ReasonScheduled, fmt.Sprintf("next run at %s", nextRun.Format(time.RFC3339)))
+
+	executor.WatchChanges(cleanerScope.Cleaner, logger)
+
+	requeueAfter := nextRun.Sub(now)
+	// A run was either just queued or is still going on: come back shortly to
+	// collect its result, rather than only at the next scheduled run.
+	runPending := result.ResultStatus == executor.InProgress ||
+		cleanerScope.Cleaner.Status.LastRunTime != previousLastRunTime
+	if runPending && requeueAfter > runResultPollInterval {
+		requeueAfter = runResultPollInterval
+	}
 
 	logger.Info("reconcile Cleaner succeeded")
-	scheduledResult := ctrl.Result{RequeueAfter: nextRun.Sub(now)}
+	scheduledResult := ctrl.Result{RequeueAfter: requeueAfter}
 	return scheduledResult, nil
 }
 
+// setRunStatus updates LastRunStatistics and the LastRunSucceeded,
+// BlastRadiusExceeded and NotificationFailed conditions from the result of
+// the most recent run.
+func setRunStatus(cleanerScope *scope.CleanerScope, result *executor.Result) {
+	if result.Statistics != nil {
+		cleanerScope.SetLastRunStatistics(result.Statistics)
+	}
+
+	if result.Err != nil {
+		reason := appsv1alpha1.ReasonFailed
+		var limitErr *executor.LuaLimitError
+		if errors.As(result.Err, &limitErr) {
+			reason = limitErr.Reason()
+		}
+		cleanerScope.SetCondition(appsv1alpha1.ConditionTypeLastRunSucceeded, metav1.ConditionFalse,
+			reason, result.Err.Error())
+	} else {
+		cleanerScope.SetCondition(appsv1alpha1.ConditionTypeLastRunSucceeded, metav1.ConditionTrue,
+			appsv1alpha1.ReasonSucceeded, "")
+	}
+
+	if result.Statistics == nil {
+		// The run did not start, so nothing is known about blast radius
+		// or notifications.
+		return
+	}
+
+	if result.BlastRadiusExceeded {
+		cleanerScope.SetCondition(appsv1alpha1.ConditionTypeBlastRadiusExceeded, metav1.ConditionTrue,
+			appsv1alpha1.ReasonLimitExceeded, fmt.Sprintf("%d resources matched",
+				result.Statistics.MatchedCount))
+	} else {
+		cleanerScope.SetCondition(appsv1alpha1.ConditionTypeBlastRadiusExceeded, metav1.ConditionFalse,
+			appsv1alpha1.ReasonWithinLimit, "")
+	}
+
+	if result.NotificationErr != nil {
+		cleanerScope.SetCondition(appsv1alpha1.ConditionTypeNotificationFailed, metav1.ConditionTrue,
+			appsv1alpha1.ReasonNotificationError, result.NotificationErr.Error())
+	} else {
+		cleanerScope.SetCondition(appsv1alpha1.ConditionTypeNotificationFailed, metav1.ConditionFalse,
+			appsv1alpha1.ReasonNotificationsSent, "")
+	}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *CleanerReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager,
 	numOfWorker int, logger logr.Logger) error {
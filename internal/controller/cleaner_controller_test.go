@@ -27,12 +27,14 @@ import (
 	"go.uber.org/zap"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2/textlogger"
 
 	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
 	"gianlucam76/k8s-cleaner/internal/controller"
+	"gianlucam76/k8s-cleaner/internal/controller/executor"
 	"gianlucam76/k8s-cleaner/pkg/scope"
 )
 
@@ -180,4 +182,52 @@ var _ = Describe("CleanerClient", func() {
 
 		Expect(controllerutil.ContainsFinalizer(currentCleaner, appsv1alpha1.CleanerFinalizer)).To(BeTrue())
 	})
+
+	It("setRunStatus fills statistics and conditions from the run result", func() {
+		cleaner := &appsv1alpha1.Cleaner{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       randomString(),
+				Generation: 3,
+			},
+		}
+
+		cleanerScope, err := scope.NewCleanerScope(scope.CleanerScopeParams{
+			Cleaner: cleaner,
+			Client:  k8sClient,
+		})
+		Expect(err).To(BeNil())
+
+		statistics := &appsv1alpha1.RunStatistics{ScannedCount: 10, MatchedCount: 4, ProcessedCount: 3, FailedCount: 1}
+		controller.SetRunStatus(cleanerScope, &executor.Result{
+			ResultStatus:        executor.Failed,
+			Err:                 fmt.Errorf("failed to delete resource"),
+			Statistics:          statistics,
+			BlastRadiusExceeded: true,
+			NotificationErr:     fmt.Errorf("slack unreachable"),
+		})
+
+		Expect(cleaner.Status.LastRunStatistics).To(Equal(statistics))
+		for _, conditionType := range []string{appsv1alpha1.ConditionTypeBlastRadiusExceeded,
+			appsv1alpha1.ConditionTypeNotificationFailed} {
+
+			Expect(meta.IsStatusConditionTrue(cleaner.Status.Conditions, conditionType)).To(BeTrue())
+		}
+		condition := meta.FindStatusCondition(cleaner.Status.Conditions, appsv1alpha1.ConditionTypeLastRunSucceeded)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Message).To(Equal("failed to delete resource"))
+		Expect(condition.ObservedGeneration).To(Equal(int64(3)))
+
+		By("a successful run flips the conditions back")
+		controller.SetRunStatus(cleanerScope, &executor.Result{
+			ResultStatus: executor.Processed,
+			Statistics:   &appsv1alpha1.RunStatistics{},
+		})
+		Expect(meta.IsStatusConditionTrue(cleaner.Status.Conditions,
+			appsv1alpha1.ConditionTypeLastRunSucceeded)).To(BeTrue())
+		Expect(meta.IsStatusConditionFalse(cleaner.Status.Conditions,
+			appsv1alpha1.ConditionTypeBlastRadiusExceeded)).To(BeTrue())
+		Expect(meta.IsStatusConditionFalse(cleaner.Status.Conditions,
+			appsv1alpha1.ConditionTypeNotificationFailed)).To(BeTrue())
+	})
 })
@@ -0,0 +1,370 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+)
+
+const (
+	defaultOnChangeDebounce                = 10 * time.Second
+	defaultOnChangeMaxEvaluationsPerMinute = 60
+)
+
+// changeKey identifies a changed resource along with the ResourceSelector
+// (index in ResourceSelectors) it is evaluated against.
+type changeKey struct {
+	selector  int
+	namespace string
+	name      string
+}
+
+// changeWatcher evaluates the resources selected by one Cleaner as they are
+// created or updated.
+type changeWatcher struct {
+	// generation of the Cleaner the watcher was started for.
+	generation int64
+	cancel     context.CancelFunc
+	queue      workqueue.TypedDelayingInterface[changeKey]
+
+	informers     []cache.SharedIndexInformer
+	registrations []cache.ResourceEventHandlerRegistration
+}
+
+var (
+	changeWatchersMu sync.Mutex
+	// changeWatchers contains, per Cleaner name, the running watcher.
+	changeWatchers = make(map[string]*changeWatcher)
+)
+
+// onChangeEnabled returns true if resources selected by cleaner must be
+// evaluated as they change. MaxActionsPerRun budgets, and defers, all the
+// matches of a run together, so it is not compatible either.
+func onChangeEnabled(cleaner *appsv1alpha1.Cleaner) bool {
+	return cleaner.Spec.Trigger != nil && cleaner.Spec.Trigger.OnChange != nil &&
+		cleaner.Spec.ResourcePolicySet.AggregatedSelection == "" &&
+		cleaner.Spec.OccurrenceThreshold <= 1 &&
+		(cleaner.Spec.Execution == nil || cleaner.Spec.Execution.MaxActionsPerRun == nil)
+}
+
+type changeRunKey struct{}
+
+// withChangeRun returns a copy of ctx marking the run as taken on a single
+// changed resource, rather than on all the resources a Cleaner matches.
+func withChangeRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, changeRunKey{}, true)
+}
+
+// isChangeRun returns true if ctx belongs to a run on a changed resource.
+// Such a run must not forget the state recorded for resources it did not see.
+func isChangeRun(ctx context.Context) bool {
+	changeRun, _ := ctx.Value(changeRunKey{}).(bool)
+	return changeRun
+}
+
+// onChangeInformerUser is the name watchers acquire informers with, distinct
+// from the Cleaner name used by scheduled runs reading from the cache.
+func onChangeInformerUser(cleanerName string) string {
+	return cleanerName + "/onChange"
+}
+
+// WatchChanges starts evaluating the resources selected by cleaner as they
+// change if cleaner.Spec.Trigger.OnChange is set, and stops it otherwise.
+// A watcher already running for the same generation of cleaner is kept.
+func WatchChanges(cleaner *appsv1alpha1.Cleaner, logger logr.Logger) {
+	changeWatchersMu.Lock()
+	defer changeWatchersMu.Unlock()
+
+	if w, ok := changeWatchers[cleaner.Name]; ok {
+		if w.generation == cleaner.Generation {
+			return
+		}
+		w.stop()
+		delete(changeWatchers, cleaner.Name)
+	}
+
+	if !onChangeEnabled(cleaner) || informers == nil {
+		if informers != nil {
+			informers.setUsage(onChangeInformerUser(cleaner.Name), nil, logger)
+		}
+		return
+	}
+
+	changeWatchers[cleaner.Name] = startChangeWatcher(cleaner.DeepCopy(), logger)
+}
+
+// StopWatchingChanges stops evaluating the resources selected by the Cleaner
+// as they change. It is called when a Cleaner is deleted.
+func StopWatchingChanges(cleanerName string, logger logr.Logger) {
+	changeWatchersMu.Lock()
+	defer changeWatchersMu.Unlock()
+
+	if w, ok := changeWatchers[cleanerName]; ok {
+		w.stop()
+		delete(changeWatchers, cleanerName)
+	}
+	if informers != nil {
+		informers.setUsage(onChangeInformerUser(cleanerName), nil, logger)
+	}
+}
+
+func startChangeWatcher(cleaner *appsv1alpha1.Cleaner, logger logr.Logger) *changeWatcher {
+	logger = logger.WithValues("trigger", "onChange")
+	onChange := cleaner.Spec.Trigger.OnChange
+
+	debounce := defaultOnChangeDebounce
+	if onChange.Debounce != nil {
+		debounce = onChange.Debounce.Duration
+	}
+	maxPerMinute := defaultOnChangeMaxEvaluationsPerMinute
+	if onChange.MaxEvaluationsPerMinute != nil && *onChange.MaxEvaluationsPerMinute > 0 {
+		maxPerMinute = int(*onChange.MaxEvaluationsPerMinute)
+	}
+
+	ctx, cancel := context.WithCancel(informers.ctx)
+	w := &changeWatcher{
+		generation: cleaner.Generation,
+		cancel:     cancel,
+		queue:      workqueue.NewTypedDelayingQueue[changeKey](),
+	}
+
+	// selectorsByGVR lists, per resource type, the ResourceSelectors selecting it.
+	selectorsByGVR := make(map[schema.GroupVersionResource][]int)
+	for i := range cleaner.Spec.ResourcePolicySet.ResourceSelectors {
+		sr := &cleaner.Spec.ResourcePolicySet.ResourceSelectors[i]
+		gvk := schema.GroupVersionKind{Group: sr.Group, Version: sr.Version, Kind: sr.Kind}
+		gvr, err := getResourceForKind(config, gvk)
+		if err != nil {
+			logger.V(logs.LogInfo).Info(fmt.Sprintf("cannot watch %s: %v", gvk.String(), err))
+			continue
+		}
+		selectorsByGVR[gvr] = append(selectorsByGVR[gvr], i)
+	}
+
+	gvrs := make(map[schema.GroupVersionResource]bool, len(selectorsByGVR))
+	for gvr := range selectorsByGVR {
+		gvrs[gvr] = true
+	}
+	informers.setUsage(onChangeInformerUser(cleaner.Name), gvrs, logger)
+
+	informerBySelector := make(map[int]cache.SharedIndexInformer)
+	for gvr, selectors := range selectorsByGVR {
+		informer := informers.informer(gvr)
+		if informer == nil {
+			continue
+		}
+		for _, i := range selectors {
+			informerBySelector[i] = informer
+		}
+
+		enqueue := func(obj interface{}) {
+			u, ok := obj.(*unstructured.Unstructured)
+			if !ok {
+				return
+			}
+			for _, i := range selectors {
+				// Changes already queued are coalesced.
+				w.queue.AddAfter(changeKey{selector: i, namespace: u.GetNamespace(), name: u.GetName()}, debounce)
+			}
+		}
+
+		registration, err := informer.AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+			AddFunc: func(obj interface{}, isInInitialList bool) {
+				// Resources existing when the watch starts are left to scheduled runs.
+				if !isInInitialList {
+					enqueue(obj)
+				}
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				oldU, oldOk := oldObj.(*unstructured.Unstructured)
+				newU, newOk := newObj.(*unstructured.Unstructured)
+				// Resyncs deliver updates with no actual change.
+				if oldOk && newOk && oldU.GetResourceVersion() == newU.GetResourceVersion() {
+					return
+				}
+				enqueue(newObj)
+			},
+		})
+		if err != nil {
+			logger.V(logs.LogInfo).Info(fmt.Sprintf("failed to watch %s: %v", gvr.String(), err))
+			continue
+		}
+		w.informers = append(w.informers, informer)
+		w.registrations = append(w.registrations, registration)
+	}
+
+	limiter := rate.NewLimiter(rate.Limit(float64(maxPerMinute)/60), 1)
+	go func() {
+		for {
+			key, shutdown := w.queue.Get()
+			if shutdown {
+				return
+			}
+			if err := limiter.Wait(ctx); err != nil {
+				w.queue.Done(key)
+				return
+			}
+			processChange(ctx, cleaner, informerBySelector[key.selector], key, logger)
+			w.queue.Done(key)
+		}
+	}()
+
+	return w
+}
+
+func (w *changeWatcher) stop() {
+	for i := range w.informers {
+		_ = w.informers[i].RemoveEventHandler(w.registrations[i])
+	}
+	w.cancel()
+	w.queue.ShutDown()
+}
+
+// processChange evaluates a changed resource against the ResourceSelector it
+// was selected by and, if it is a match, takes cleaner's Action on it the way
+// a scheduled run would: rollback snapshot, BlastRadiusLimit, Execution,
+// Notifications and execution history included.
+func processChange(ctx context.Context, cleaner *appsv1alpha1.Cleaner, informer cache.SharedIndexInformer,
+	key changeKey, logger logr.Logger) {
+
+	if informer == nil {
+		return
+	}
+
+	if err := ValidateRollbackConfig(cleaner); err != nil {
+		logger.Info(fmt.Sprintf("invalid rollback configuration: %v", err))
+		return
+	}
+
+	if err := luaScripts.load(cleaner); err != nil {
+		logger.Info(fmt.Sprintf("failed to compile Lua scripts: %v", err))
+		return
+	}
+
+	storeKey := key.name
+	if key.namespace != "" {
+		storeKey = key.namespace + "/" + key.name
+	}
+	obj, exists, err := informer.GetIndexer().GetByKey(storeKey)
+	if err != nil || !exists {
+		return
+	}
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	resource := u.DeepCopy()
+
+	l := logger.WithValues("resource", fmt.Sprintf("%s:%s/%s",
+		resource.GetKind(), resource.GetNamespace(), resource.GetName()))
+
+	sr := &cleaner.Spec.ResourcePolicySet.ResourceSelectors[key.selector]
+	startTime := time.Now()
+	lookups := newLookupCache(k8sClient)
+	if isSelected, err := isSelectedBy(ctx, sr, resource, lookups, l); err != nil || !isSelected {
+		return
+	}
+
+	metricsData, err := fetchMetrics(ctx, sr, l)
+	if err != nil {
+		l.Info(fmt.Sprintf("failed to fetch metrics: %v", err))
+		return
+	}
+
+	result, err := evaluateResource(ctx, sr, resource, cleaner.Spec.LuaLimits,
+		lookups, metricsData, selectsPods(sr), nil, l)
+	if err != nil || !result.Matching {
+		return
+	}
+	l.Info(fmt.Sprintf("changed resource is a match %q", result.Message))
+
+	resources := []ResourceResult{{Resource: resource, Message: result.Message, Replicas: result.Replicas}}
+	stats := &runStats{startTime: startTime, scanned: 1, matched: 1}
+	// A single resource was scanned: MaxPercentage does not apply.
+	err = actOnMatches(withChangeRun(ctx), cleaner, resources, 0, stats, l)
+	if err != nil {
+		l.Info(fmt.Sprintf("failed to process changed resource: %v", err))
+	}
+	stats.endTime = time.Now()
+
+	// A failure to record history must not mask the outcome of the run.
+	_ = recordExecution(ctx, cleaner, stats, err, l)
+	storeChangeResult(cleaner.Name, stats, err)
+}
+
+// isSelectedBy returns true if resource, already known to be of the kind
+// selected by sr, is also in one of its namespaces, not in an excluded one, and
+// matches its LabelFilters, FieldSelector, OwnerFilter, OlderThan and
+// ExcludeDeleted.
+func isSelectedBy(ctx context.Context, sr *appsv1alpha1.ResourceSelector, resource *unstructured.Unstructured,
+	lookups *lookupCache, logger logr.Logger) (bool, error) {
+
+	if sr.ExcludeDeleted && !resource.GetDeletionTimestamp().IsZero() {
+		return false, nil
+	}
+
+	namespaces, err := getNamespaces(ctx, sr, logger)
+	if err != nil {
+		return false, err
+	}
+	if len(namespaces) > 0 && !slices.Contains(namespaces, resource.GetNamespace()) {
+		return false, nil
+	}
+
+	excluded, err := getExcludedNamespaces(ctx, sr, logger)
+	if err != nil {
+		return false, err
+	}
+	if excluded[resource.GetNamespace()] {
+		return false, nil
+	}
+
+	selector, err := labels.Parse(addLabelFilters(sr.LabelFilters))
+	if err != nil {
+		return false, err
+	}
+	if !selector.Matches(labels.Set(resource.GetLabels())) {
+		return false, nil
+	}
+
+	fieldSelector, err := fields.ParseSelector(sr.FieldSelector)
+	if err != nil {
+		return false, err
+	}
+	if !matchesFieldSelector(fieldSelector, resource) {
+		return false, nil
+	}
+
+	return passesFilters(ctx, sr, resource, lookups)
+}
@@ -0,0 +1,300 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor_test
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+	"gianlucam76/k8s-cleaner/internal/controller/executor"
+)
+
+const evaluateDeleteLabel = `function evaluate(obj)
+  hs = {}
+  hs.matching = obj.metadata.labels ~= nil and obj.metadata.labels["delete"] == "true"
+  return hs
+end`
+
+var _ = Describe("OnChange trigger", func() {
+	var ns *corev1.Namespace
+
+	BeforeEach(func() {
+		ns = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString()},
+		}
+		Expect(k8sClient.Create(context.TODO(), ns)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, ns)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), ns)).To(Succeed())
+	})
+
+	createConfigMap := func(labels map[string]string) *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name, Name: randomString(), Labels: labels},
+		}
+		Expect(k8sClient.Create(context.TODO(), cm)).To(Succeed())
+		return cm
+	}
+
+	exists := func(cm *corev1.ConfigMap) bool {
+		err := k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: cm.Namespace, Name: cm.Name},
+			&corev1.ConfigMap{})
+		return !apierrors.IsNotFound(err)
+	}
+
+	It("evaluates and deletes resources as they are created", func() {
+		existing := createConfigMap(map[string]string{"delete": "true"})
+		Expect(waitForObject(context.TODO(), k8sClient, existing)).To(Succeed())
+
+		cleaner := &appsv1alpha1.Cleaner{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString(), Generation: 1},
+			Spec: appsv1alpha1.CleanerSpec{
+				Schedule: "0 0 * * *",
+				Action:   appsv1alpha1.ActionDelete,
+				ResourcePolicySet: appsv1alpha1.ResourcePolicySet{
+					ResourceSelectors: []appsv1alpha1.ResourceSelector{
+						{Namespace: ns.Name, Kind: kindConfigMap, Version: apiVersionV1, Evaluate: evaluateDeleteLabel},
+					},
+				},
+				Trigger: &appsv1alpha1.Trigger{
+					OnChange: &appsv1alpha1.OnChangeTrigger{Debounce: &metav1.Duration{}},
+				},
+			},
+		}
+		executor.WatchChanges(cleaner, logr.Discard())
+		defer executor.StopWatchingChanges(cleaner.Name, logr.Discard())
+
+		configMapGVR := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+		Eventually(func() bool {
+			return executor.IsInformerRunning(configMapGVR)
+		}, timeout, pollingInterval).Should(BeTrue())
+
+		matching := createConfigMap(map[string]string{"delete": "true"})
+		nonMatching := createConfigMap(nil)
+
+		Eventually(func() bool {
+			return exists(matching)
+		}, timeout, pollingInterval).Should(BeFalse())
+		Consistently(func() bool {
+			return exists(nonMatching)
+		}, timeout/5, pollingInterval).Should(BeTrue())
+
+		By("resources existing before the watch started are left to scheduled runs")
+		Expect(exists(existing)).To(BeTrue())
+	})
+
+	It("records changed resources it acts on in the execution history", func() {
+		cleaner := &appsv1alpha1.Cleaner{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString()},
+			Spec: appsv1alpha1.CleanerSpec{
+				Schedule:              "0 0 * * *",
+				Action:                appsv1alpha1.ActionDelete,
+				ExecutionHistoryLimit: ptr.To(int32(5)),
+				ResourcePolicySet: appsv1alpha1.ResourcePolicySet{
+					ResourceSelectors: []appsv1alpha1.ResourceSelector{
+						{Namespace: ns.Name, Kind: kindConfigMap, Version: apiVersionV1, Evaluate: evaluateDeleteLabel},
+					},
+				},
+				Trigger: &appsv1alpha1.Trigger{
+					OnChange: &appsv1alpha1.OnChangeTrigger{Debounce: &metav1.Duration{}},
+				},
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), cleaner)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, cleaner)).To(Succeed())
+		defer func() {
+			Expect(k8sClient.Delete(context.TODO(), cleaner)).To(Succeed())
+		}()
+
+		executor.WatchChanges(cleaner, logr.Discard())
+		defer executor.StopWatchingChanges(cleaner.Name, logr.Discard())
+
+		configMapGVR := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+		Eventually(func() bool {
+			return executor.IsInformerRunning(configMapGVR)
+		}, timeout, pollingInterval).Should(BeTrue())
+
+		matching := createConfigMap(map[string]string{"delete": "true"})
+
+		Eventually(func() bool {
+			records, err := executor.ListExecutionRecords(context.TODO(), k8sClient, cleaner.Name)
+			return err == nil && len(records) == 1 && records[0].Spec.ProcessedCount == 1
+		}, timeout, pollingInterval).Should(BeTrue())
+
+		records, err := executor.ListExecutionRecords(context.TODO(), k8sClient, cleaner.Name)
+		Expect(err).To(BeNil())
+		Expect(records[0].Spec.MatchedCount).To(Equal(1))
+		Expect(records[0].Spec.Resources).To(HaveLen(1))
+		Expect(records[0].Spec.Resources[0].Resource.Name).To(Equal(matching.Name))
+		Expect(exists(matching)).To(BeFalse())
+	})
+
+	It("leaves the deletion marks of resources other than the changed one alone", func() {
+		// The ConfigMap recording marks is created in the controller namespace.
+		os.Setenv("NAMESPACE", ns.Name)
+
+		cleaner := &appsv1alpha1.Cleaner{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString(), UID: types.UID(randomString()), Generation: 1},
+			Spec: appsv1alpha1.CleanerSpec{
+				Schedule: "0 0 * * *",
+				Action:   appsv1alpha1.ActionDelete,
+				DeleteOptions: &appsv1alpha1.DeleteOptions{
+					TwoPhase: &appsv1alpha1.TwoPhaseDelete{GracePeriod: metav1.Duration{Duration: time.Hour}},
+				},
+				ResourcePolicySet: appsv1alpha1.ResourcePolicySet{
+					ResourceSelectors: []appsv1alpha1.ResourceSelector{
+						{Namespace: ns.Name, Kind: kindConfigMap, Version: apiVersionV1, Evaluate: evaluateDeleteLabel},
+					},
+				},
+				Trigger: &appsv1alpha1.Trigger{
+					OnChange: &appsv1alpha1.OnChangeTrigger{Debounce: &metav1.Duration{}},
+				},
+			},
+		}
+		defer func() {
+			Expect(executor.DeleteConfigMap(context.TODO(), cleaner)).To(Succeed())
+		}()
+
+		get := func(cm *corev1.ConfigMap) *unstructured.Unstructured {
+			u := &unstructured.Unstructured{}
+			u.SetAPIVersion(apiVersionV1)
+			u.SetKind(kindConfigMap)
+			Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: cm.Namespace, Name: cm.Name},
+				u)).To(Succeed())
+			return u
+		}
+		getMarks := func() map[string]string {
+			marks, err := executor.GetCleanerConfigMapData(context.TODO(), cleaner, executor.DeletionMarksSuffix)
+			Expect(err).To(BeNil())
+			return marks
+		}
+		scheduledRun := func(cms ...*corev1.ConfigMap) {
+			resources := make([]executor.ResourceResult, len(cms))
+			for i := range cms {
+				resources[i] = executor.ResourceResult{Resource: get(cms[i])}
+			}
+			_, failed, _, err := executor.DeleteResources(context.TODO(), cleaner, cleaner.Spec.DeleteOptions,
+				resources, false, logr.Discard())
+			Expect(err).To(BeNil())
+			Expect(failed).To(BeEmpty())
+		}
+
+		By("a scheduled run marks a resource and records another one as rescued")
+		marked := createConfigMap(map[string]string{"delete": "true"})
+		rescued := createConfigMap(map[string]string{"delete": "true"})
+		Expect(waitForObject(context.TODO(), k8sClient, marked)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, rescued)).To(Succeed())
+		scheduledRun(marked, rescued)
+
+		u := get(rescued)
+		u.SetAnnotations(nil)
+		Expect(k8sClient.Update(context.TODO(), u)).To(Succeed())
+		Eventually(func() bool {
+			_, ok := get(rescued).GetAnnotations()[appsv1alpha1.DeleteAfterAnnotation]
+			return ok
+		}, timeout, pollingInterval).Should(BeFalse())
+		scheduledRun(marked, rescued)
+
+		markedKey := executor.GetResourceKey(get(marked))
+		rescuedKey := executor.GetResourceKey(get(rescued))
+		marks := getMarks()
+		Expect(marks).To(HaveKey(markedKey))
+		Expect(executor.DeletionMarkDeleteAfter(marks[rescuedKey])).To(Equal(executor.RescuedMark))
+		deadline := marks[markedKey]
+
+		By("a change on another resource only adds its own mark")
+		executor.WatchChanges(cleaner, logr.Discard())
+		defer executor.StopWatchingChanges(cleaner.Name, logr.Discard())
+
+		configMapGVR := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+		Eventually(func() bool {
+			return executor.IsInformerRunning(configMapGVR)
+		}, timeout, pollingInterval).Should(BeTrue())
+
+		changed := createConfigMap(map[string]string{"delete": "true"})
+		Eventually(func() bool {
+			_, ok := get(changed).GetAnnotations()[appsv1alpha1.DeleteAfterAnnotation]
+			return ok
+		}, timeout, pollingInterval).Should(BeTrue())
+
+		Eventually(func() bool {
+			_, ok := getMarks()[executor.GetResourceKey(get(changed))]
+			return ok
+		}, timeout, pollingInterval).Should(BeTrue())
+		marks = getMarks()
+		Expect(marks).To(HaveKeyWithValue(markedKey, deadline))
+		Expect(executor.DeletionMarkDeleteAfter(marks[rescuedKey])).To(Equal(executor.RescuedMark))
+	})
+
+	It("does not watch when maxActionsPerRun is set", func() {
+		cleaner := &appsv1alpha1.Cleaner{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString(), Generation: 1},
+			Spec: appsv1alpha1.CleanerSpec{
+				Schedule: "0 0 * * *",
+				Action:   appsv1alpha1.ActionDelete,
+				ResourcePolicySet: appsv1alpha1.ResourcePolicySet{
+					ResourceSelectors: []appsv1alpha1.ResourceSelector{
+						{Namespace: ns.Name, Kind: "Secret", Version: apiVersionV1, Evaluate: evaluateDeleteLabel},
+					},
+				},
+				Execution: &appsv1alpha1.ExecutionOptions{MaxActionsPerRun: ptr.To(int32(10))},
+				Trigger:   &appsv1alpha1.Trigger{OnChange: &appsv1alpha1.OnChangeTrigger{}},
+			},
+		}
+		executor.WatchChanges(cleaner, logr.Discard())
+		defer executor.StopWatchingChanges(cleaner.Name, logr.Discard())
+
+		Expect(executor.IsInformerRunning(schema.GroupVersionResource{Version: "v1", Resource: "secrets"})).To(BeFalse())
+	})
+
+	It("does not watch when AggregatedSelection is set", func() {
+		cleaner := &appsv1alpha1.Cleaner{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString(), Generation: 1},
+			Spec: appsv1alpha1.CleanerSpec{
+				Schedule: "0 0 * * *",
+				Action:   appsv1alpha1.ActionDelete,
+				ResourcePolicySet: appsv1alpha1.ResourcePolicySet{
+					ResourceSelectors: []appsv1alpha1.ResourceSelector{
+						{Namespace: ns.Name, Kind: "Secret", Version: apiVersionV1},
+					},
+					AggregatedSelection: evaluateDeleteLabel,
+				},
+				Trigger: &appsv1alpha1.Trigger{OnChange: &appsv1alpha1.OnChangeTrigger{}},
+			},
+		}
+		executor.WatchChanges(cleaner, logr.Discard())
+		defer executor.StopWatchingChanges(cleaner.Name, logr.Discard())
+
+		Expect(executor.IsInformerRunning(schema.GroupVersionResource{Version: "v1", Resource: "secrets"})).To(BeFalse())
+	})
+})
@@ -30,6 +30,8 @@ import (
 	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+
 	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
 )
 
@@ -69,6 +71,15 @@ type Result struct {
 	ResultStatus
 	Message string
 	Err     error
+
+	// Statistics summarizes the run. Nil unless the run started.
+	Statistics *appsv1alpha1.RunStatistics
+
+	// BlastRadiusExceeded is set when the run was aborted by BlastRadiusLimit.
+	BlastRadiusExceeded bool
+
+	// NotificationErr is the error sending Notifications, if any.
+	NotificationErr error
 }
 
 type Manager struct {
@@ -93,6 +104,10 @@ type Manager struct {
 	// results contains results for processed requests (cleaner names)
 	results map[string]error
 
+	// runStats contains statistics for processed requests (cleaner names).
+	// Entries are added and removed together with results.
+	runStats map[string]*runStats
+
 	eventRecorder events.EventRecorder
 }
 
@@ -130,19 +145,29 @@ func (m *Manager) startWorkloadWorkers(ctx context.Context, numOfWorker int, log
 	m.inProgress = make([]string, 0)
 	m.jobQueue = make([]string, 0)
 	m.results = make(map[string]error)
+	m.runStats = make(map[string]*runStats)
 	k8sClient = m.Client
 	config = m.config
 	scheme = m.scheme
 
 	cs, err := kubernetes.NewForConfig(m.config)
 	if err != nil {
-		// LogSource fetches will fail for individual resources; nothing else
-		// in the executor depends on clientset, so this is not fatal.
-		logger.Error(err, "failed to build Kubernetes clientset; LogSource evaluation will be unavailable")
+		// LogSource fetches and drains will fail for individual resources;
+		// nothing else in the executor depends on clientset, so this is not fatal.
+		logger.Error(err, "failed to build Kubernetes clientset; LogSource evaluation and Drain will be unavailable")
 	} else {
 		clientset = cs
 	}
 
+	ic, err := newInformerCache(ctx, m.config)
+	if err != nil {
+		// ResourceSelectors setting UseCache fall back to listing from the
+		// API server.
+		logger.Error(err, "failed to build dynamic client; informer cache will be unavailable")
+	} else {
+		informers = ic
+	}
+
 	for i := 0; i < numOfWorker; i++ {
 		go processRequests(ctx, i, logger.WithValues("worker", fmt.Sprintf("%d", i)))
 	}
@@ -173,6 +198,7 @@ func (m *Manager) Process(ctx context.Context, cleanerName string) {
 	// Since we got a new request, if a result was saved, clear it.
 	l.V(logs.LogDebug).Info("removing result from previous request if any")
 	delete(m.results, key)
+	delete(m.runStats, key)
 
 	m.log.V(logs.LogDebug).Info("request added to dirty")
 	m.dirty = append(m.dirty, key)
@@ -205,16 +231,20 @@ func (m *Manager) GetResult(cleanerName string) Result {
 		}
 	}
 
+	result := Result{
+		ResultStatus: Processed,
+	}
 	if responseParam.err != nil {
-		return Result{
-			ResultStatus: Failed,
-			Err:          responseParam.err,
-		}
+		result.ResultStatus = Failed
+		result.Err = responseParam.err
 	}
-
-	return Result{
-		ResultStatus: Processed,
+	if responseParam.stats != nil {
+		result.Statistics = responseParam.stats.statistics()
+		result.BlastRadiusExceeded = responseParam.stats.blastRadiusExceeded
+		result.NotificationErr = responseParam.stats.notificationErr
 	}
+
+	return result
 }
 
 func (m *Manager) RemoveEntries(cleanerName string) {
@@ -245,4 +275,5 @@ func (m *Manager) RemoveEntries(cleanerName string) {
 	}
 
 	delete(m.results, key)
+	delete(m.runStats, key)
 }
@@ -19,6 +19,7 @@ package executor_test
 import (
 	"context"
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -57,6 +58,29 @@ var _ = Describe("CleanerClient", func() {
 		Expect(result.ResultStatus).To(Equal(executor.Failed))
 	})
 
+	It("GetResult returns run statistics when available", func() {
+		cleanerName := randomString()
+
+		d := executor.GetClient()
+		defer d.ClearInternalStruct()
+
+		r := map[string]error{cleanerName: nil}
+		d.SetResults(r)
+		start := time.Now()
+		stats := executor.NewRunStats(start, 5, 2, make([]executor.ResourceResult, 2), nil, true)
+		executor.SetRunStatsEndTime(stats, start.Add(time.Second))
+		d.SetRunStats(cleanerName, stats)
+
+		result := d.GetResult(cleanerName)
+		Expect(result.ResultStatus).To(Equal(executor.Processed))
+		Expect(result.BlastRadiusExceeded).To(BeTrue())
+		Expect(result.Statistics).ToNot(BeNil())
+		Expect(result.Statistics.ScannedCount).To(Equal(5))
+		Expect(result.Statistics.MatchedCount).To(Equal(2))
+		Expect(result.Statistics.ProcessedCount).To(Equal(2))
+		Expect(result.Statistics.Duration.Duration).To(Equal(time.Second))
+	})
+
 	It("GetResult returns InProgress when request is still queued (currently in progress)", func() {
 		cleanerName := randomString()
 
@@ -0,0 +1,49 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"sync/atomic"
+)
+
+const defaultEvaluationConcurrency = 4
+
+// evaluationConcurrency is used by Cleaners not setting
+// EvaluationConcurrency.
+var evaluationConcurrency atomic.Int32
+
+func init() {
+	evaluationConcurrency.Store(defaultEvaluationConcurrency)
+}
+
+// SetEvaluationConcurrency sets the controller-wide number of resources of a
+// ResourceSelector evaluated concurrently. A value lower than one leaves the
+// default unchanged.
+func SetEvaluationConcurrency(concurrency int) {
+	if concurrency > 0 {
+		evaluationConcurrency.Store(int32(concurrency))
+	}
+}
+
+// resolveEvaluationConcurrency returns the configured concurrency of a Cleaner
+// (possibly nil), falling back to the controller-wide default.
+func resolveEvaluationConcurrency(concurrency *int32) int {
+	if concurrency != nil && *concurrency > 0 {
+		return int(*concurrency)
+	}
+	return int(evaluationConcurrency.Load())
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+)
+
+const (
+	defaultDrainTimeout = 5 * time.Minute
+
+	// mirrorPodAnnotation is set on the API server copy of static Pods,
+	// which cannot be evicted.
+	mirrorPodAnnotation = "kubernetes.io/config.mirror"
+)
+
+// drainPollInterval is how often a drain checks whether the Pods of a Node
+// are gone, retrying the evictions that were blocked.
+var drainPollInterval = 5 * time.Second
+
+// drainMatchingResources cordons Nodes and evicts their Pods. A Node whose
+// Pods are not all evicted within the drain timeout is returned as skipped,
+// stays cordoned, and is drained further by the next run if it still matches.
+// Protected Pods are never evicted: they are returned as skipped, and so is
+// the Node running them, which is left cordoned.
+func drainMatchingResources(ctx context.Context, cleanerName string, resources []ResourceResult,
+	drainOptions *appsv1alpha1.DrainOptions, dryRun bool, logger logr.Logger,
+) (processedResources, failedResources, skippedResources []ResourceResult, err error) {
+
+	processedResources = make([]ResourceResult, 0)
+	var failedActions []error // Slice to store all errors
+
+	resources, skippedResources = skipProtectedResources(ctx, cleanerName, resources, logger)
+
+	if drainOptions == nil {
+		drainOptions = &appsv1alpha1.DrainOptions{}
+	}
+
+	if !dryRun {
+		reportUpdatedCount(cleanerName, float64(len(resources)))
+	}
+
+	numberOfErrors := 0
+	for i := range resources {
+		resource := resources[i]
+		l := logger.WithValues("node", resource.Resource.GetName())
+
+		var message string
+		var drained bool
+		var drainErr error
+		if resource.Resource.GroupVersionKind() != corev1.SchemeGroupVersion.WithKind("Node") {
+			drainErr = errors.New("only Nodes can be drained")
+		} else {
+			waitForAction(ctx)
+			l.Info("draining node")
+			var protectedPods []ResourceResult
+			drained, message, protectedPods, drainErr = drainNode(ctx, cleanerName, resource.Resource,
+				drainOptions, dryRun, l)
+			skippedResources = append(skippedResources, protectedPods...)
+		}
+		if drainErr != nil {
+			if apierrors.IsNotFound(drainErr) {
+				continue
+			}
+			numberOfErrors++
+			reportErrorEvent(cleanerName, resource.Resource.GetAPIVersion(),
+				resource.Resource.GetKind())
+			l.Info(fmt.Sprintf("failed to drain node: %v", drainErr))
+			failedActions = append(failedActions, fmt.Errorf("%s %s: %w", resource.Resource.GetKind(),
+				resource.Resource.GetName(), drainErr))
+			failedResources = append(failedResources, ResourceResult{Resource: resource.Resource,
+				Message: drainErr.Error()})
+			continue
+		}
+
+		l.Info(message)
+		if !drained {
+			skippedResources = append(skippedResources, ResourceResult{Resource: resource.Resource,
+				Message: message})
+			continue
+		}
+
+		resource.Message = message
+		resource.Removed = drainOptions.DeleteNode
+		processedResources = append(processedResources, resource)
+		if !dryRun {
+			reportUpdateEvent(cleanerName, resource.Resource.GetAPIVersion(),
+				resource.Resource.GetKind())
+		}
+	}
+
+	if len(failedActions) > 0 {
+		// Use errors.Join to combine all collected errors into a single error
+		return processedResources, failedResources, skippedResources, errors.Join(failedActions...)
+	}
+
+	reportErrorCount(cleanerName, float64(numberOfErrors))
+
+	return processedResources, failedResources, skippedResources, nil
+}
+
+// drainNode cordons node and evicts its Pods, waiting up to the drain timeout
+// for them to be gone, then deletes node if requested. It returns whether the
+// drain completed along with a message describing its progress. Pods protected
+// from Cleaner cleanerName are left in place and returned: a Node running any
+// is not drained.
+func drainNode(ctx context.Context, cleanerName string, node *unstructured.Unstructured,
+	drainOptions *appsv1alpha1.DrainOptions, dryRun bool, logger logr.Logger,
+) (drained bool, message string, protectedPods []ResourceResult, err error) {
+
+	if err := cordonNode(ctx, node, dryRun); err != nil {
+		return false, "", nil, err
+	}
+
+	timeout := defaultDrainTimeout
+	if drainOptions.Timeout != nil {
+		timeout = drainOptions.Timeout.Duration
+	}
+	deadline := time.Now().Add(timeout)
+
+	evicted := make(map[types.NamespacedName]bool)
+	for {
+		pods, err := getPodsToEvict(ctx, node.GetName())
+		if err != nil {
+			return false, "", nil, err
+		}
+		pods, protectedPods = skipProtectedResources(ctx, cleanerName, pods, logger)
+
+		blocked := 0
+		for i := range pods {
+			pod := pods[i].Resource
+			key := types.NamespacedName{Namespace: pod.GetNamespace(), Name: pod.GetName()}
+			if evicted[key] || pod.GetDeletionTimestamp() != nil {
+				continue
+			}
+			err := evictPod(ctx, key.Namespace, key.Name, drainOptions.GracePeriodSeconds, dryRun)
+			switch {
+			case err == nil, apierrors.IsNotFound(err):
+				evicted[key] = true
+			case apierrors.IsTooManyRequests(err):
+				blocked++
+				logger.V(logs.LogDebug).Info(fmt.Sprintf("eviction of pod %s blocked: %v", key, err))
+			default:
+				return false, "", nil, fmt.Errorf("failed to evict pod %s: %w", key, err)
+			}
+		}
+
+		// In dry run nothing is actually evicted, so there is nothing to wait for.
+		if dryRun || len(pods) == 0 {
+			break
+		}
+
+		if !time.Now().Before(deadline) {
+			return false, fmt.Sprintf("cordoned, %d pod(s) evicted, %d pod(s) remaining (%d blocked), "+
+				"retried on next run", len(evicted), len(pods)+len(protectedPods), blocked), protectedPods, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return false, "", nil, ctx.Err()
+		case <-time.After(drainPollInterval):
+		}
+	}
+
+	// The Node is not empty, so it is neither drained nor deleted.
+	if len(protectedPods) > 0 {
+		return false, fmt.Sprintf("cordoned, %d pod(s) evicted, %d protected pod(s) left in place",
+			len(evicted), len(protectedPods)), protectedPods, nil
+	}
+
+	if drainOptions.DeleteNode {
+		if err := k8sClient.Delete(ctx, node, &client.DeleteOptions{DryRun: dryRunOption(dryRun)}); err != nil {
+			return false, "", nil, err
+		}
+		return true, fmt.Sprintf("drained, %d pod(s) evicted, node deleted", len(evicted)), nil, nil
+	}
+
+	return true, fmt.Sprintf("drained, %d pod(s) evicted", len(evicted)), nil, nil
+}
+
+// cordonNode marks node as unschedulable.
+func cordonNode(ctx context.Context, node *unstructured.Unstructured, dryRun bool) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"unschedulable": true,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return k8sClient.Patch(ctx, node, client.RawPatch(types.MergePatchType, patch),
+		&client.PatchOptions{DryRun: dryRunOption(dryRun)})
+}
+
+// getPodsToEvict returns the Pods running on Node nodeName a drain evicts:
+// Pods managed by a DaemonSet, which would be recreated on the Node anyway,
+// static Pods and terminated Pods are left alone.
+// Pods are listed from the API server, filtered there by Node: k8sClient reads
+// from the manager cache, which has no index on spec.nodeName and would have
+// to watch every Pod of the cluster.
+func getPodsToEvict(ctx context.Context, nodeName string) ([]ResourceResult, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes clientset is not available")
+	}
+	podList, err := clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pods := make([]ResourceResult, 0, len(podList.Items))
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+			continue
+		}
+		if isDaemonSetPod(pod) {
+			continue
+		}
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pod)
+		if err != nil {
+			return nil, err
+		}
+		u := &unstructured.Unstructured{Object: content}
+		u.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Pod"))
+		pods = append(pods, ResourceResult{Resource: u})
+	}
+
+	return pods, nil
+}
+
+func isDaemonSetPod(pod *corev1.Pod) bool {
+	for i := range pod.OwnerReferences {
+		ref := &pod.OwnerReferences[i]
+		if ref.Controller != nil && *ref.Controller && ref.Kind == "DaemonSet" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor_test
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+	"gianlucam76/k8s-cleaner/internal/controller/executor"
+)
+
+var _ = Describe("Drain", func() {
+	var ns *corev1.Namespace
+	var node *corev1.Node
+
+	BeforeEach(func() {
+		ns = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString()},
+		}
+		Expect(k8sClient.Create(context.TODO(), ns)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, ns)).To(Succeed())
+
+		node = &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString()},
+		}
+		Expect(k8sClient.Create(context.TODO(), node)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, node)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), ns)).To(Succeed())
+		err := k8sClient.Delete(context.TODO(), node)
+		Expect(err == nil || apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	nodeResource := func() executor.ResourceResult {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("Node")
+		u.SetName(node.Name)
+		return executor.ResourceResult{Resource: u}
+	}
+
+	createPodWithAnnotations := func(ownerReferences []metav1.OwnerReference,
+		annotations map[string]string) *corev1.Pod {
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       ns.Name,
+				Name:            randomString(),
+				Labels:          map[string]string{"app": "web"},
+				Annotations:     annotations,
+				OwnerReferences: ownerReferences,
+			},
+			Spec: corev1.PodSpec{
+				NodeName:   node.Name,
+				Containers: []corev1.Container{{Name: "app", Image: "nginx:1.25.3"}},
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), pod)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, pod)).To(Succeed())
+
+		pod.Status.Phase = corev1.PodRunning
+		Expect(k8sClient.Status().Update(context.TODO(), pod)).To(Succeed())
+		return pod
+	}
+
+	createPod := func(ownerReferences []metav1.OwnerReference) *corev1.Pod {
+		return createPodWithAnnotations(ownerReferences, nil)
+	}
+
+	podExists := func(pod *corev1.Pod) bool {
+		err := k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name},
+			&corev1.Pod{})
+		return !apierrors.IsNotFound(err)
+	}
+
+	isCordoned := func() bool {
+		current := &corev1.Node{}
+		Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Name: node.Name}, current)).To(Succeed())
+		return current.Spec.Unschedulable
+	}
+
+	It("cordons the Node and evicts its Pods, leaving DaemonSet Pods alone", func() {
+		pod := createPod(nil)
+		dsPod := createPod([]metav1.OwnerReference{
+			{APIVersion: "apps/v1", Kind: "DaemonSet", Name: randomString(), UID: types.UID(randomString()),
+				Controller: ptr.To(true)},
+		})
+
+		processed, failed, skipped, err := executor.DrainMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{nodeResource()},
+			&appsv1alpha1.DrainOptions{GracePeriodSeconds: ptr.To(int64(0))}, false, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(failed).To(BeEmpty())
+		Expect(skipped).To(BeEmpty())
+		Expect(processed).To(HaveLen(1))
+		Expect(processed[0].Message).To(Equal("drained, 1 pod(s) evicted"))
+
+		Expect(isCordoned()).To(BeTrue())
+		Expect(podExists(pod)).To(BeFalse())
+		Expect(podExists(dsPod)).To(BeTrue())
+	})
+
+	It("deletes the Node once drained when requested", func() {
+		createPod(nil)
+
+		processed, _, _, err := executor.DrainMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{nodeResource()},
+			&appsv1alpha1.DrainOptions{GracePeriodSeconds: ptr.To(int64(0)), DeleteNode: true}, false, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(processed).To(HaveLen(1))
+
+		err = k8sClient.Get(context.TODO(), types.NamespacedName{Name: node.Name}, &corev1.Node{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("reports a Node whose Pods a PodDisruptionBudget protects as not yet drained", func() {
+		pod := createPod(nil)
+
+		pdb := &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name, Name: randomString()},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				MinAvailable: ptr.To(intstr.FromInt32(1)),
+				Selector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), pdb)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, pdb)).To(Succeed())
+
+		processed, failed, skipped, err := executor.DrainMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{nodeResource()},
+			&appsv1alpha1.DrainOptions{Timeout: &metav1.Duration{}, DeleteNode: true}, false, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(failed).To(BeEmpty())
+		Expect(processed).To(BeEmpty())
+		Expect(skipped).To(HaveLen(1))
+		Expect(skipped[0].Message).To(ContainSubstring("1 pod(s) remaining (1 blocked)"))
+
+		Expect(isCordoned()).To(BeTrue())
+		Expect(podExists(pod)).To(BeTrue())
+	})
+
+	It("lists the Pods of the Node when the executor client reads from a cache", func() {
+		pod := createPod(nil)
+
+		informerCache, err := cache.New(config, cache.Options{Scheme: scheme})
+		Expect(err).To(BeNil())
+		ctx, cancel := context.WithCancel(context.TODO())
+		defer cancel()
+		go func() {
+			defer GinkgoRecover()
+			Expect(informerCache.Start(ctx)).To(Succeed())
+		}()
+		Expect(informerCache.WaitForCacheSync(ctx)).To(BeTrue())
+
+		cachedClient, err := client.New(config, client.Options{Scheme: scheme,
+			Cache: &client.CacheOptions{Reader: informerCache}})
+		Expect(err).To(BeNil())
+		previous := executor.SetK8sClient(cachedClient)
+		defer executor.SetK8sClient(previous)
+
+		processed, failed, _, err := executor.DrainMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{nodeResource()},
+			&appsv1alpha1.DrainOptions{GracePeriodSeconds: ptr.To(int64(0))}, false, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(failed).To(BeEmpty())
+		Expect(processed).To(HaveLen(1))
+		Expect(podExists(pod)).To(BeFalse())
+	})
+
+	It("leaves protected Pods in place and does not delete their Node", func() {
+		pod := createPod(nil)
+		protectedPod := createPodWithAnnotations(nil, map[string]string{appsv1alpha1.ProtectAnnotation: "true"})
+
+		processed, failed, skipped, err := executor.DrainMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{nodeResource()},
+			&appsv1alpha1.DrainOptions{GracePeriodSeconds: ptr.To(int64(0)), DeleteNode: true}, false, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(failed).To(BeEmpty())
+		Expect(processed).To(BeEmpty())
+		Expect(skipped).To(HaveLen(2))
+		Expect(skipped[0].Resource.GetKind()).To(Equal("Pod"))
+		Expect(skipped[0].Resource.GetName()).To(Equal(protectedPod.Name))
+		Expect(skipped[0].Message).To(ContainSubstring(appsv1alpha1.ProtectAnnotation))
+		Expect(skipped[1].Resource.GetKind()).To(Equal("Node"))
+		Expect(skipped[1].Message).To(Equal("cordoned, 1 pod(s) evicted, 1 protected pod(s) left in place"))
+
+		Expect(isCordoned()).To(BeTrue())
+		Expect(podExists(pod)).To(BeFalse())
+		Expect(podExists(protectedPod)).To(BeTrue())
+	})
+
+	It("leaves the Node and its Pods untouched in dry run", func() {
+		pod := createPod(nil)
+
+		processed, _, _, err := executor.DrainMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{nodeResource()}, nil, true, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(processed).To(HaveLen(1))
+
+		Expect(isCordoned()).To(BeFalse())
+		Expect(podExists(pod)).To(BeTrue())
+	})
+})
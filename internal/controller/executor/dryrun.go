@@ -0,0 +1,87 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"encoding/json"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+)
+
+// isDryRun returns true if cleaner's Delete/Transform requests must be sent
+// to the API server in dry-run mode. DryRun is meaningless for Scan, which
+// never sends any.
+func isDryRun(cleaner *appsv1alpha1.Cleaner) bool {
+	return cleaner.Spec.DryRun && takesAction(cleaner)
+}
+
+// dryRunOption returns the server-side dry-run option to attach to a
+// mutating request, or nil when dryRun is false.
+func dryRunOption(dryRun bool) []string {
+	if !dryRun {
+		return nil
+	}
+	return []string{metav1.DryRunAll}
+}
+
+// updateOptions returns the client.UpdateOption list for a Transform update.
+func updateOptions(dryRun bool) []client.UpdateOption {
+	if !dryRun {
+		return nil
+	}
+	return []client.UpdateOption{client.DryRunAll}
+}
+
+// computeDiff returns the JSON merge patch turning original into updated.
+// Fields the API server changes on every write (resourceVersion,
+// managedFields, generation) are ignored, so the diff only shows what
+// the transform function actually changed.
+func computeDiff(original, updated *unstructured.Unstructured) (string, error) {
+	originalJSON, err := diffableJSON(original)
+	if err != nil {
+		return "", err
+	}
+
+	updatedJSON, err := diffableJSON(updated)
+	if err != nil {
+		return "", err
+	}
+
+	patch, err := jsonpatch.CreateMergePatch(originalJSON, updatedJSON)
+	if err != nil {
+		return "", err
+	}
+
+	if string(patch) == "{}" {
+		return "", nil
+	}
+
+	return string(patch), nil
+}
+
+func diffableJSON(u *unstructured.Unstructured) ([]byte, error) {
+	tmp := u.DeepCopy()
+	tmp.SetResourceVersion("")
+	tmp.SetManagedFields(nil)
+	tmp.SetGeneration(0)
+	return json.Marshal(tmp.Object)
+}
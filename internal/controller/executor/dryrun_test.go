@@ -0,0 +1,149 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor_test
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+	"gianlucam76/k8s-cleaner/internal/controller/executor"
+)
+
+const transformAddLabel = `function transform()
+  hs = {}
+  if obj.metadata.labels == nil then
+    obj.metadata.labels = {}
+  end
+  obj.metadata.labels["dry-run"] = "true"
+  hs.resource = obj
+  return hs
+end`
+
+var _ = Describe("Dry run", func() {
+	var ns *corev1.Namespace
+
+	BeforeEach(func() {
+		ns = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString()},
+		}
+		Expect(k8sClient.Create(context.TODO(), ns)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, ns)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), ns)).To(Succeed())
+	})
+
+	createConfigMap := func() *unstructured.Unstructured {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name, Name: randomString()},
+			Data:       map[string]string{"k": "v"},
+		}
+		Expect(k8sClient.Create(context.TODO(), cm)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, cm)).To(Succeed())
+
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cm)
+		Expect(err).To(BeNil())
+		u := &unstructured.Unstructured{Object: content}
+		u.SetAPIVersion(apiVersionV1)
+		u.SetKind(kindConfigMap)
+		return u
+	}
+
+	It("deleteMatchingResources does not delete resources in dry run mode", func() {
+		u := createConfigMap()
+
+		processed, _, _, err := executor.DeleteMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{{Resource: u}}, nil, true, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(processed).To(HaveLen(1))
+
+		currentCm := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(context.TODO(),
+			types.NamespacedName{Namespace: u.GetNamespace(), Name: u.GetName()}, currentCm)).To(Succeed())
+	})
+
+	It("updateMatchingResources reports the diff without updating resources in dry run mode", func() {
+		u := createConfigMap()
+
+		processed, _, _, err := executor.UpdateMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{{Resource: u}}, transformAddLabel, nil, nil, true, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(processed).To(HaveLen(1))
+		Expect(processed[0].Diff).To(ContainSubstring(`"dry-run":"true"`))
+
+		currentCm := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(context.TODO(),
+			types.NamespacedName{Namespace: u.GetNamespace(), Name: u.GetName()}, currentCm)).To(Succeed())
+		Expect(currentCm.Labels).ToNot(HaveKey("dry-run"))
+	})
+
+	It("computeDiff ignores fields the API server changes on every write", func() {
+		original := newConfigMapResourceResult(randomString(), randomString(), map[string]string{"k": "v"}).Resource
+		original.SetResourceVersion("1")
+
+		updated := original.DeepCopy()
+		updated.SetResourceVersion("2")
+		updated.SetGeneration(3)
+
+		diff, err := executor.ComputeDiff(original, updated)
+		Expect(err).To(BeNil())
+		Expect(diff).To(BeEmpty())
+
+		Expect(unstructured.SetNestedField(updated.Object, "changed", "data", "k")).To(Succeed())
+		diff, err = executor.ComputeDiff(original, updated)
+		Expect(err).To(BeNil())
+		Expect(diff).To(Equal(`{"data":{"k":"changed"}}`))
+	})
+
+	It("generateReportSpec marks the report and carries diffs in dry run mode", func() {
+		resource := newConfigMapResourceResult(randomString(), randomString(), nil)
+		resource.Diff = `{"data":{"k":"changed"}}`
+
+		cleaner := newRollbackCleaner(randomString(), appsv1alpha1.ActionTransform, nil)
+		cleaner.Spec.DryRun = true
+
+		reportSpec := executor.GenerateReportSpec([]executor.ResourceResult{resource}, cleaner)
+		Expect(reportSpec.DryRun).To(BeTrue())
+		Expect(reportSpec.ResourceInfo[0].Diff).To(Equal(resource.Diff))
+
+		cleaner.Spec.Action = appsv1alpha1.ActionScan
+		reportSpec = executor.GenerateReportSpec([]executor.ResourceResult{resource}, cleaner)
+		Expect(reportSpec.DryRun).To(BeFalse())
+	})
+
+	It("addRollbackResourceData never populates FullResource in dry run mode", func() {
+		resources := []executor.ResourceResult{newConfigMapResourceResult(randomString(), randomString(), nil)}
+		cleaner := newRollbackCleaner(randomString(), appsv1alpha1.ActionDelete,
+			&appsv1alpha1.RollbackOptions{Storage: appsv1alpha1.RollbackStorageReport})
+		cleaner.Spec.DryRun = true
+
+		reportSpec := executor.GenerateReportSpec(resources, cleaner)
+		reportSpec = executor.AddRollbackResourceData(reportSpec, resources, cleaner, logr.Discard())
+		Expect(reportSpec.ResourceInfo[0].FullResource).To(BeEmpty())
+	})
+})
@@ -189,7 +189,7 @@ function evaluate(obj)
   return hs
 end`, reasonFailedMount)
 
-		matching, message, err := executor.IsMatch(resource, script, nil, events, nil, nil, logr.Logger{})
+		matching, message, err := executor.IsMatch(context.TODO(), resource, script, nil, nil, nil, events, nil, nil, logr.Logger{})
 		Expect(err).ToNot(HaveOccurred())
 		Expect(matching).To(BeTrue())
 		Expect(message).To(Equal("unable to mount volume"))
@@ -0,0 +1,136 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+)
+
+const (
+	evictionSubresource = "eviction"
+
+	evictedMessage = "evicted"
+)
+
+// evictMatchingResources evicts Pods through the Eviction API. Evictions
+// refused because of a PodDisruptionBudget are not failures: those Pods are
+// returned as skipped, and retried by the next run if they still match.
+func evictMatchingResources(ctx context.Context, cleanerName string, resources []ResourceResult,
+	evictOptions *appsv1alpha1.EvictOptions, dryRun bool, logger logr.Logger,
+) (processedResources, failedResources, skippedResources []ResourceResult, err error) {
+
+	processedResources = make([]ResourceResult, 0)
+	var failedActions []error // Slice to store all errors
+
+	resources, skippedResources = skipProtectedResources(ctx, cleanerName, resources, logger)
+
+	var gracePeriodSeconds *int64
+	if evictOptions != nil {
+		gracePeriodSeconds = evictOptions.GracePeriodSeconds
+	}
+
+	if !dryRun {
+		reportDeletedCount(cleanerName, float64(len(resources)))
+	}
+
+	numberOfErrors := 0
+	for i := range resources {
+		resource := resources[i]
+		l := logger.WithValues("resource", fmt.Sprintf("%s:%s/%s",
+			resource.Resource.GetKind(),
+			resource.Resource.GetNamespace(),
+			resource.Resource.GetName()))
+
+		var evictErr error
+		if resource.Resource.GroupVersionKind() != corev1.SchemeGroupVersion.WithKind("Pod") {
+			evictErr = errors.New("only Pods can be evicted")
+		} else {
+			waitForAction(ctx)
+			l.Info("evicting pod")
+			evictErr = evictPod(ctx, resource.Resource.GetNamespace(), resource.Resource.GetName(),
+				gracePeriodSeconds, dryRun)
+		}
+		if evictErr != nil {
+			if apierrors.IsNotFound(evictErr) {
+				continue
+			}
+			if apierrors.IsTooManyRequests(evictErr) {
+				l.Info(fmt.Sprintf("eviction blocked: %v", evictErr))
+				skippedResources = append(skippedResources, ResourceResult{Resource: resource.Resource,
+					Message: fmt.Sprintf("eviction blocked, retried on next run: %v", evictErr)})
+				continue
+			}
+			numberOfErrors++
+			reportErrorEvent(cleanerName, resource.Resource.GetAPIVersion(),
+				resource.Resource.GetKind())
+			l.Info(fmt.Sprintf("failed to evict resource: %v", evictErr))
+			failedActions = append(failedActions, fmt.Errorf("%s %s/%s: %w", resource.Resource.GetKind(),
+				resource.Resource.GetNamespace(), resource.Resource.GetName(), evictErr))
+			failedResources = append(failedResources, ResourceResult{Resource: resource.Resource,
+				Message: evictErr.Error()})
+			continue
+		}
+
+		if resource.Message == "" {
+			resource.Message = evictedMessage
+		} else {
+			resource.Message = fmt.Sprintf("%s: %s", evictedMessage, resource.Message)
+		}
+		resource.Removed = true
+		processedResources = append(processedResources, resource)
+		if !dryRun {
+			reportDeletionEvent(cleanerName, resource.Resource.GetAPIVersion(),
+				resource.Resource.GetKind())
+		}
+	}
+
+	if len(failedActions) > 0 {
+		// Use errors.Join to combine all collected errors into a single error
+		return processedResources, failedResources, skippedResources, errors.Join(failedActions...)
+	}
+
+	reportErrorCount(cleanerName, float64(numberOfErrors))
+
+	return processedResources, failedResources, skippedResources, nil
+}
+
+// evictPod evicts Pod namespace/name. The API server answers with
+// TooManyRequests when a PodDisruptionBudget does not allow the eviction.
+func evictPod(ctx context.Context, namespace, name string, gracePeriodSeconds *int64, dryRun bool) error {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+	}
+	eviction := &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		DeleteOptions: &metav1.DeleteOptions{
+			GracePeriodSeconds: gracePeriodSeconds,
+			DryRun:             dryRunOption(dryRun),
+		},
+	}
+
+	return k8sClient.SubResource(evictionSubresource).Create(ctx, pod, eviction)
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor_test
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	"gianlucam76/k8s-cleaner/internal/controller/executor"
+)
+
+var _ = Describe("Evict", func() {
+	var ns *corev1.Namespace
+
+	BeforeEach(func() {
+		ns = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString()},
+		}
+		Expect(k8sClient.Create(context.TODO(), ns)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, ns)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), ns)).To(Succeed())
+	})
+
+	// createPod creates a running Pod. Pods still pending are evicted
+	// regardless of PodDisruptionBudgets.
+	createPod := func() executor.ResourceResult {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ns.Name,
+				Name:      randomString(),
+				Labels:    map[string]string{"app": "web"},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "app", Image: "nginx:1.25.3"}},
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), pod)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, pod)).To(Succeed())
+
+		pod.Status.Phase = corev1.PodRunning
+		Expect(k8sClient.Status().Update(context.TODO(), pod)).To(Succeed())
+
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pod)
+		Expect(err).To(BeNil())
+		u := &unstructured.Unstructured{Object: content}
+		u.SetAPIVersion("v1")
+		u.SetKind("Pod")
+		return executor.ResourceResult{Resource: u}
+	}
+
+	isEvicted := func(resource executor.ResourceResult) bool {
+		pod := &corev1.Pod{}
+		err := k8sClient.Get(context.TODO(), types.NamespacedName{
+			Namespace: resource.Resource.GetNamespace(), Name: resource.Resource.GetName()}, pod)
+		return apierrors.IsNotFound(err) || (err == nil && !pod.DeletionTimestamp.IsZero())
+	}
+
+	It("evicts matching Pods", func() {
+		resource := createPod()
+
+		processed, failed, skipped, err := executor.EvictMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{resource}, nil, false, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(failed).To(BeEmpty())
+		Expect(skipped).To(BeEmpty())
+		Expect(processed).To(HaveLen(1))
+		Expect(processed[0].Message).To(Equal("evicted"))
+
+		Expect(isEvicted(resource)).To(BeTrue())
+	})
+
+	It("skips Pods whose eviction a PodDisruptionBudget blocks", func() {
+		resource := createPod()
+
+		pdb := &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name, Name: randomString()},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				MinAvailable: ptr.To(intstr.FromInt32(1)),
+				Selector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), pdb)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, pdb)).To(Succeed())
+
+		processed, failed, skipped, err := executor.EvictMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{resource}, nil, false, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(failed).To(BeEmpty())
+		Expect(processed).To(BeEmpty())
+		Expect(skipped).To(HaveLen(1))
+		Expect(skipped[0].Message).To(ContainSubstring("eviction blocked"))
+
+		Expect(isEvicted(resource)).To(BeFalse())
+	})
+
+	It("leaves Pods untouched in dry run", func() {
+		resource := createPod()
+
+		processed, _, _, err := executor.EvictMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{resource}, nil, true, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(processed).To(HaveLen(1))
+
+		Expect(isEvicted(resource)).To(BeFalse())
+	})
+
+	It("fails on resources other than Pods", func() {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name, Name: randomString()},
+		}
+		Expect(k8sClient.Create(context.TODO(), cm)).To(Succeed())
+
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cm)
+		Expect(err).To(BeNil())
+		u := &unstructured.Unstructured{Object: content}
+		u.SetAPIVersion("v1")
+		u.SetKind("ConfigMap")
+
+		processed, failed, _, err := executor.EvictMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{{Resource: u}}, nil, false, logr.Discard())
+		Expect(err).ToNot(BeNil())
+		Expect(processed).To(BeEmpty())
+		Expect(failed).To(HaveLen(1))
+	})
+})
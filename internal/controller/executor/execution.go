@@ -0,0 +1,144 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/time/rate"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+)
+
+// actionPacer spaces out the requests a run sends to take its Action, as
+// configured by a Cleaner's ExecutionOptions.
+type actionPacer struct {
+	limiter    *rate.Limiter
+	batchSize  int
+	batchPause time.Duration
+	actions    int
+}
+
+type actionPacerKey struct{}
+
+// withActionPacer returns a copy of ctx carrying the pacer of execution.
+// Actions taken with the returned context are paced by waitForAction.
+func withActionPacer(ctx context.Context, execution *appsv1alpha1.ExecutionOptions) context.Context {
+	if execution == nil {
+		return ctx
+	}
+
+	pacer := &actionPacer{}
+	if execution.MaxActionsPerSecond != nil {
+		pacer.limiter = rate.NewLimiter(rate.Limit(*execution.MaxActionsPerSecond), 1)
+	}
+	if execution.BatchPause != nil {
+		pacer.batchPause = execution.BatchPause.Duration
+		pacer.batchSize = 1
+		if execution.BatchSize != nil {
+			pacer.batchSize = int(*execution.BatchSize)
+		}
+	}
+
+	return context.WithValue(ctx, actionPacerKey{}, pacer)
+}
+
+// waitForAction blocks until the pacer carried by ctx, if any, allows one
+// more action. It returns early if ctx is done: the request that follows
+// then fails with ctx's error.
+func waitForAction(ctx context.Context) {
+	pacer, ok := ctx.Value(actionPacerKey{}).(*actionPacer)
+	if !ok {
+		return
+	}
+
+	if pacer.batchPause > 0 && pacer.actions > 0 && pacer.actions%pacer.batchSize == 0 {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(pacer.batchPause):
+		}
+	}
+	pacer.actions++
+
+	if pacer.limiter != nil {
+		_ = pacer.limiter.Wait(ctx)
+	}
+}
+
+// deferredResourcesSuffix identifies the ConfigMap recording the keys of the
+// resources the previous run deferred.
+const deferredResourcesSuffix = "deferred"
+
+// deferResources caps resources to cleaner's Execution.MaxActionsPerRun.
+// Protected resources are not counted: they are left for the Action to skip,
+// and report. Resources deferred by the previous run come first, so that
+// resources an Action does not make stop matching are not deferred forever.
+// The others are returned as deferred, and recorded unless in dry run.
+func deferResources(ctx context.Context, cleaner *appsv1alpha1.Cleaner, resources []ResourceResult,
+	dryRun bool, logger logr.Logger) (toProcess, deferred []ResourceResult, err error) {
+
+	if cleaner.Spec.Execution == nil || cleaner.Spec.Execution.MaxActionsPerRun == nil {
+		return resources, nil, nil
+	}
+
+	maxActions := int(*cleaner.Spec.Execution.MaxActionsPerRun)
+	previous, err := getCleanerConfigMapData(ctx, cleaner, deferredResourcesSuffix)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	allowed, protected := skipProtectedResources(ctx, cleaner.Name, resources, logger)
+
+	ordered := make([]ResourceResult, 0, len(allowed))
+	for i := range allowed {
+		if _, ok := previous[getResourceKey(allowed[i].Resource)]; ok {
+			ordered = append(ordered, allowed[i])
+		}
+	}
+	for i := range allowed {
+		if _, ok := previous[getResourceKey(allowed[i].Resource)]; !ok {
+			ordered = append(ordered, allowed[i])
+		}
+	}
+
+	toProcess = ordered
+	keys := make(map[string]string)
+	if len(ordered) > maxActions {
+		toProcess = ordered[:maxActions]
+		for i := range ordered[maxActions:] {
+			resource := ordered[maxActions+i]
+			keys[getResourceKey(resource.Resource)] = ""
+			deferred = append(deferred, ResourceResult{Resource: resource.Resource,
+				Message: fmt.Sprintf("deferred: maxActionsPerRun (%d) reached", maxActions)})
+		}
+	}
+	toProcess = append(toProcess, protected...)
+
+	if dryRun {
+		return toProcess, deferred, nil
+	}
+
+	if err := updateCleanerConfigMapData(ctx, cleaner, deferredResourcesSuffix, keys); err != nil {
+		return nil, nil, err
+	}
+
+	return toProcess, deferred, nil
+}
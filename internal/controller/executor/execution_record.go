@@ -0,0 +1,203 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=apps.projectsveltos.io,resources=executionrecords,verbs=get;list;watch;create;delete;deletecollection
+
+const (
+	// maxExecutionRecordResources caps the per-resource outcomes stored in a
+	// single ExecutionRecord, to keep it well below the etcd object size limit.
+	// Counters are always complete.
+	maxExecutionRecordResources = 500
+)
+
+// runStats collects what happened during a single Cleaner run.
+type runStats struct {
+	startTime           time.Time
+	endTime             time.Time
+	scanned             int
+	matched             int
+	processed           []ResourceResult
+	failed              []ResourceResult
+	skipped             []ResourceResult
+	deferred            []ResourceResult
+	steps               []appsv1alpha1.StepReport
+	blastRadiusExceeded bool
+	notificationErr     error
+}
+
+// statistics returns the counters exposed in CleanerStatus.
+func (s *runStats) statistics() *appsv1alpha1.RunStatistics {
+	return &appsv1alpha1.RunStatistics{
+		ScannedCount:   s.scanned,
+		MatchedCount:   s.matched,
+		ProcessedCount: len(s.processed),
+		FailedCount:    len(s.failed),
+		SkippedCount:   len(s.skipped) + len(s.deferred),
+		Duration:       metav1.Duration{Duration: s.endTime.Sub(s.startTime).Round(time.Millisecond)},
+	}
+}
+
+// recordExecution creates an ExecutionRecord describing the run and prunes
+// records beyond cleaner's ExecutionHistoryLimit. Nothing is recorded when
+// ExecutionHistoryLimit is not set.
+func recordExecution(ctx context.Context, cleaner *appsv1alpha1.Cleaner, stats *runStats, runErr error,
+	logger logr.Logger) error {
+
+	if cleaner.Spec.ExecutionHistoryLimit == nil {
+		return nil
+	}
+
+	record := generateExecutionRecord(cleaner, stats, runErr, stats.endTime)
+	if err := k8sClient.Create(ctx, record); err != nil {
+		logger.Info(fmt.Sprintf("failed to create ExecutionRecord: %v", err))
+		return err
+	}
+
+	return pruneExecutionRecords(ctx, cleaner, record, logger)
+}
+
+func generateExecutionRecord(cleaner *appsv1alpha1.Cleaner, stats *runStats, runErr error,
+	endTime time.Time) *appsv1alpha1.ExecutionRecord {
+
+	record := &appsv1alpha1.ExecutionRecord{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: cleaner.Name + "-",
+			Labels: map[string]string{
+				appsv1alpha1.ExecutionRecordCleanerLabel: cleaner.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cleaner, appsv1alpha1.GroupVersion.WithKind("Cleaner")),
+			},
+		},
+		Spec: appsv1alpha1.ExecutionRecordSpec{
+			CleanerName:         cleaner.Name,
+			StartTime:           metav1.NewTime(stats.startTime),
+			EndTime:             metav1.NewTime(endTime),
+			Action:              cleanerAction(cleaner),
+			DryRun:              isDryRun(cleaner),
+			ScannedCount:        stats.scanned,
+			MatchedCount:        stats.matched,
+			ProcessedCount:      len(stats.processed),
+			FailedCount:         len(stats.failed),
+			SkippedCount:        len(stats.skipped),
+			DeferredCount:       len(stats.deferred),
+			BlastRadiusExceeded: stats.blastRadiusExceeded,
+		},
+	}
+
+	if runErr != nil {
+		record.Spec.FailureMessage = runErr.Error()
+	}
+
+	record.Spec.Resources = make([]appsv1alpha1.ExecutionRecordResource, 0)
+	add := func(resources []ResourceResult, outcome appsv1alpha1.ResourceOutcome) {
+		for i := range resources {
+			if len(record.Spec.Resources) == maxExecutionRecordResources {
+				record.Spec.ResourcesTruncated = true
+				return
+			}
+			resource := resources[i].Resource
+			record.Spec.Resources = append(record.Spec.Resources, appsv1alpha1.ExecutionRecordResource{
+				Resource: corev1.ObjectReference{
+					Kind:       resource.GetKind(),
+					Namespace:  resource.GetNamespace(),
+					Name:       resource.GetName(),
+					APIVersion: resource.GetAPIVersion(),
+				},
+				Outcome: outcome,
+				Message: resources[i].Message,
+			})
+		}
+	}
+	// Failures first so they survive truncation.
+	add(stats.failed, appsv1alpha1.ResourceOutcomeFailed)
+	add(stats.processed, appsv1alpha1.ResourceOutcomeProcessed)
+	add(stats.skipped, appsv1alpha1.ResourceOutcomeSkipped)
+	add(stats.deferred, appsv1alpha1.ResourceOutcomeDeferred)
+
+	return record
+}
+
+// ListExecutionRecords returns all ExecutionRecords for cleanerName, newest first.
+func ListExecutionRecords(ctx context.Context, c client.Client, cleanerName string,
+) ([]appsv1alpha1.ExecutionRecord, error) {
+
+	records := &appsv1alpha1.ExecutionRecordList{}
+	err := c.List(ctx, records, client.MatchingLabels{appsv1alpha1.ExecutionRecordCleanerLabel: cleanerName})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(records.Items, func(i, j int) bool {
+		return records.Items[j].Spec.StartTime.Before(&records.Items[i].Spec.StartTime)
+	})
+
+	return records.Items, nil
+}
+
+// pruneExecutionRecords deletes the oldest ExecutionRecords for cleaner
+// beyond its ExecutionHistoryLimit. created, the record just created, is
+// counted even when the client reads from a cache not yet showing it.
+func pruneExecutionRecords(ctx context.Context, cleaner *appsv1alpha1.Cleaner,
+	created *appsv1alpha1.ExecutionRecord, logger logr.Logger) error {
+
+	if cleaner.Spec.ExecutionHistoryLimit == nil {
+		return nil
+	}
+
+	records, err := ListExecutionRecords(ctx, k8sClient, cleaner.Name)
+	if err != nil {
+		return err
+	}
+
+	if !slices.ContainsFunc(records, func(r appsv1alpha1.ExecutionRecord) bool { return r.Name == created.Name }) {
+		// It is the newest record.
+		records = slices.Insert(records, 0, *created)
+	}
+
+	limit := int(*cleaner.Spec.ExecutionHistoryLimit)
+	for i := limit; i < len(records); i++ {
+		if err := k8sClient.Delete(ctx, &records[i]); client.IgnoreNotFound(err) != nil {
+			logger.Info(fmt.Sprintf("failed to prune ExecutionRecord %s: %v", records[i].Name, err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteExecutionRecords removes all ExecutionRecords created for cleaner.
+func DeleteExecutionRecords(ctx context.Context, cleaner *appsv1alpha1.Cleaner) error {
+	return k8sClient.DeleteAllOf(ctx, &appsv1alpha1.ExecutionRecord{},
+		client.MatchingLabels{appsv1alpha1.ExecutionRecordCleanerLabel: cleaner.Name})
+}
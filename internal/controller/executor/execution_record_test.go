@@ -0,0 +1,186 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+	"gianlucam76/k8s-cleaner/internal/controller/executor"
+)
+
+var _ = Describe("Execution records", func() {
+	newCleaner := func(limit *int32) *appsv1alpha1.Cleaner {
+		return &appsv1alpha1.Cleaner{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString()},
+			Spec: appsv1alpha1.CleanerSpec{
+				Action:                appsv1alpha1.ActionDelete,
+				Schedule:              "* * * * *",
+				ExecutionHistoryLimit: limit,
+			},
+		}
+	}
+
+	listRecords := func(cleanerName string) []appsv1alpha1.ExecutionRecord {
+		records := &appsv1alpha1.ExecutionRecordList{}
+		Expect(k8sClient.List(context.TODO(), records,
+			client.MatchingLabels{appsv1alpha1.ExecutionRecordCleanerLabel: cleanerName})).To(Succeed())
+		return records.Items
+	}
+
+	It("generateExecutionRecord captures counters and per-resource outcomes", func() {
+		cleaner := newCleaner(ptr.To(int32(3)))
+		processed := []executor.ResourceResult{newConfigMapResourceResult(randomString(), randomString(), nil)}
+		failed := []executor.ResourceResult{newConfigMapResourceResult(randomString(), randomString(), nil)}
+		failed[0].Message = "forbidden"
+
+		start := time.Now()
+		stats := executor.NewRunStats(start, 10, 2, processed, failed, false)
+		record := executor.GenerateExecutionRecord(cleaner, stats, errors.New("run failed"), start.Add(time.Second))
+
+		Expect(record.Labels).To(HaveKeyWithValue(appsv1alpha1.ExecutionRecordCleanerLabel, cleaner.Name))
+		Expect(record.Spec.CleanerName).To(Equal(cleaner.Name))
+		Expect(record.Spec.ScannedCount).To(Equal(10))
+		Expect(record.Spec.MatchedCount).To(Equal(2))
+		Expect(record.Spec.ProcessedCount).To(Equal(1))
+		Expect(record.Spec.FailedCount).To(Equal(1))
+		Expect(record.Spec.FailureMessage).To(Equal("run failed"))
+		Expect(record.Spec.Resources).To(HaveLen(2))
+		Expect(record.Spec.Resources[0].Outcome).To(Equal(appsv1alpha1.ResourceOutcomeFailed))
+		Expect(record.Spec.Resources[0].Message).To(Equal("forbidden"))
+		Expect(record.Spec.Resources[1].Outcome).To(Equal(appsv1alpha1.ResourceOutcomeProcessed))
+	})
+
+	It("generateExecutionRecord records skipped and deferred resources", func() {
+		cleaner := newCleaner(ptr.To(int32(3)))
+		skipped := []executor.ResourceResult{newConfigMapResourceResult(randomString(), randomString(), nil)}
+		skipped[0].Message = "protected"
+		deferred := []executor.ResourceResult{newConfigMapResourceResult(randomString(), randomString(), nil)}
+
+		stats := executor.NewRunStats(time.Now(), 3, 2, nil, nil, false)
+		executor.SetRunStatsSkipped(stats, skipped, deferred)
+		record := executor.GenerateExecutionRecord(cleaner, stats, nil, time.Now())
+
+		Expect(record.Spec.SkippedCount).To(Equal(1))
+		Expect(record.Spec.DeferredCount).To(Equal(1))
+		Expect(record.Spec.Resources).To(HaveLen(2))
+		Expect(record.Spec.Resources[0].Outcome).To(Equal(appsv1alpha1.ResourceOutcomeSkipped))
+		Expect(record.Spec.Resources[0].Message).To(Equal("protected"))
+		Expect(record.Spec.Resources[0].Resource.Name).To(Equal(skipped[0].Resource.GetName()))
+		Expect(record.Spec.Resources[1].Outcome).To(Equal(appsv1alpha1.ResourceOutcomeDeferred))
+		Expect(record.Spec.Resources[1].Resource.Name).To(Equal(deferred[0].Resource.GetName()))
+	})
+
+	It("generateExecutionRecord caps the per-resource outcomes", func() {
+		cleaner := newCleaner(ptr.To(int32(3)))
+		processed := make([]executor.ResourceResult, executor.MaxExecutionRecordResources+1)
+		for i := range processed {
+			processed[i] = newConfigMapResourceResult(randomString(), randomString(), nil)
+		}
+
+		stats := executor.NewRunStats(time.Now(), len(processed), len(processed), processed, nil, false)
+		record := executor.GenerateExecutionRecord(cleaner, stats, nil, time.Now())
+		Expect(record.Spec.ProcessedCount).To(Equal(len(processed)))
+		Expect(record.Spec.Resources).To(HaveLen(executor.MaxExecutionRecordResources))
+		Expect(record.Spec.ResourcesTruncated).To(BeTrue())
+	})
+
+	It("recordExecution does nothing when ExecutionHistoryLimit is not set", func() {
+		cleaner := newCleaner(nil)
+		Expect(k8sClient.Create(context.TODO(), cleaner)).To(Succeed())
+
+		stats := executor.NewRunStats(time.Now(), 0, 0, nil, nil, false)
+		Expect(executor.RecordExecution(context.TODO(), cleaner, stats, nil, logr.Discard())).To(Succeed())
+		Expect(listRecords(cleaner.Name)).To(BeEmpty())
+	})
+
+	It("recordExecution keeps at most ExecutionHistoryLimit records", func() {
+		cleaner := newCleaner(ptr.To(int32(2)))
+		Expect(k8sClient.Create(context.TODO(), cleaner)).To(Succeed())
+
+		start := time.Now().Add(-time.Hour)
+		for i := range 4 {
+			stats := executor.NewRunStats(start.Add(time.Duration(i)*time.Minute), 0, 0, nil, nil, false)
+			Expect(executor.RecordExecution(context.TODO(), cleaner, stats, nil, logr.Discard())).To(Succeed())
+		}
+
+		records, err := executor.ListExecutionRecords(context.TODO(), k8sClient, cleaner.Name)
+		Expect(err).To(BeNil())
+		Expect(records).To(HaveLen(2))
+		// Newest first; the two oldest runs were pruned.
+		Expect(records[0].Spec.StartTime.Time.Unix()).To(Equal(start.Add(3 * time.Minute).Unix()))
+		Expect(records[1].Spec.StartTime.Time.Unix()).To(Equal(start.Add(2 * time.Minute).Unix()))
+
+		Expect(executor.DeleteExecutionRecords(context.TODO(), cleaner)).To(Succeed())
+		Expect(listRecords(cleaner.Name)).To(BeEmpty())
+	})
+
+	It("recordExecution counts the record it creates when the client lists from a stale cache", func() {
+		cleaner := newCleaner(ptr.To(int32(2)))
+		Expect(k8sClient.Create(context.TODO(), cleaner)).To(Succeed())
+
+		c, err := client.NewWithWatch(config, client.Options{Scheme: scheme})
+		Expect(err).To(BeNil())
+		// The cache lags behind: lists miss the record created last.
+		var lastCreated string
+		staleClient := interceptor.NewClient(c, interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				err := c.Create(ctx, obj, opts...)
+				lastCreated = obj.GetName()
+				return err
+			},
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if err := c.List(ctx, list, opts...); err != nil {
+					return err
+				}
+				records := list.(*appsv1alpha1.ExecutionRecordList)
+				records.Items = slices.DeleteFunc(records.Items, func(r appsv1alpha1.ExecutionRecord) bool {
+					return r.Name == lastCreated
+				})
+				return nil
+			},
+		})
+		previous := executor.SetK8sClient(staleClient)
+		defer executor.SetK8sClient(previous)
+
+		start := time.Now().Add(-time.Hour)
+		for i := range 3 {
+			stats := executor.NewRunStats(start.Add(time.Duration(i)*time.Minute), 0, 0, nil, nil, false)
+			Expect(executor.RecordExecution(context.TODO(), cleaner, stats, nil, logr.Discard())).To(Succeed())
+		}
+
+		records, err := executor.ListExecutionRecords(context.TODO(), k8sClient, cleaner.Name)
+		Expect(err).To(BeNil())
+		Expect(records).To(HaveLen(2))
+		Expect(records[0].Spec.StartTime.Time.Unix()).To(Equal(start.Add(2 * time.Minute).Unix()))
+		Expect(records[1].Spec.StartTime.Time.Unix()).To(Equal(start.Add(time.Minute).Unix()))
+
+		Expect(executor.DeleteExecutionRecords(context.TODO(), cleaner)).To(Succeed())
+	})
+})
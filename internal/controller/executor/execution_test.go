@@ -0,0 +1,154 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor_test
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+	"gianlucam76/k8s-cleaner/internal/controller/executor"
+)
+
+var _ = Describe("Execution", func() {
+	var ns *corev1.Namespace
+	var cleaner *appsv1alpha1.Cleaner
+
+	BeforeEach(func() {
+		ns = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString()},
+		}
+		Expect(k8sClient.Create(context.TODO(), ns)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, ns)).To(Succeed())
+		// The ConfigMap recording deferred resources is created in the controller namespace.
+		os.Setenv("NAMESPACE", ns.Name)
+
+		cleaner = &appsv1alpha1.Cleaner{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: randomString(),
+				UID:  types.UID(randomString()),
+			},
+			Spec: appsv1alpha1.CleanerSpec{
+				Action: appsv1alpha1.ActionLabel,
+				Execution: &appsv1alpha1.ExecutionOptions{
+					MaxActionsPerRun: ptr.To(int32(2)),
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(executor.DeleteConfigMap(context.TODO(), cleaner)).To(Succeed())
+		Expect(k8sClient.Delete(context.TODO(), ns)).To(Succeed())
+	})
+
+	newResource := func() executor.ResourceResult {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("ConfigMap")
+		u.SetNamespace(ns.Name)
+		u.SetName(randomString())
+		u.SetUID(types.UID(randomString()))
+		return executor.ResourceResult{Resource: u}
+	}
+
+	names := func(resources []executor.ResourceResult) []string {
+		result := make([]string, len(resources))
+		for i := range resources {
+			result[i] = resources[i].Resource.GetName()
+		}
+		return result
+	}
+
+	It("defers resources beyond maxActionsPerRun, acting on them first on the next run", func() {
+		resources := []executor.ResourceResult{newResource(), newResource(), newResource(), newResource()}
+
+		toProcess, deferred, err := executor.DeferResources(context.TODO(), cleaner, resources, false, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(names(toProcess)).To(Equal(names(resources[:2])))
+		Expect(names(deferred)).To(Equal(names(resources[2:])))
+		Expect(deferred[0].Message).To(Equal("deferred: maxActionsPerRun (2) reached"))
+
+		// Resources still matching keep coming in the same order.
+		toProcess, deferred, err = executor.DeferResources(context.TODO(), cleaner, resources, false, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(names(toProcess)).To(Equal(names(resources[2:])))
+		Expect(names(deferred)).To(Equal(names(resources[:2])))
+	})
+
+	It("does not count protected resources against maxActionsPerRun", func() {
+		protected := newResource()
+		protected.Resource.SetAnnotations(map[string]string{appsv1alpha1.ProtectAnnotation: "true"})
+		resources := []executor.ResourceResult{protected, newResource(), newResource(), newResource()}
+
+		toProcess, deferred, err := executor.DeferResources(context.TODO(), cleaner, resources, false, logr.Discard())
+		Expect(err).To(BeNil())
+		// The protected resource is left for the Action to skip.
+		Expect(names(toProcess)).To(Equal(names([]executor.ResourceResult{resources[1], resources[2], protected})))
+		Expect(names(deferred)).To(Equal(names(resources[3:])))
+	})
+
+	It("does not record deferred resources in dry run", func() {
+		resources := []executor.ResourceResult{newResource(), newResource(), newResource()}
+
+		toProcess, deferred, err := executor.DeferResources(context.TODO(), cleaner, resources, true, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(toProcess).To(HaveLen(2))
+		Expect(deferred).To(HaveLen(1))
+
+		toProcess, _, err = executor.DeferResources(context.TODO(), cleaner, resources, true, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(names(toProcess)).To(Equal(names(resources[:2])))
+	})
+
+	It("pauses between batches", func() {
+		ctx := executor.WithActionPacer(context.TODO(), &appsv1alpha1.ExecutionOptions{
+			BatchSize:  ptr.To(int32(2)),
+			BatchPause: &metav1.Duration{Duration: 100 * time.Millisecond},
+		})
+
+		start := time.Now()
+		for range 5 {
+			executor.WaitForAction(ctx)
+		}
+		// Two pauses: before the third and the fifth action.
+		Expect(time.Since(start)).To(BeNumerically(">=", 200*time.Millisecond))
+	})
+
+	It("limits actions per second", func() {
+		ctx := executor.WithActionPacer(context.TODO(), &appsv1alpha1.ExecutionOptions{
+			MaxActionsPerSecond: ptr.To(int32(10)),
+		})
+
+		start := time.Now()
+		for range 4 {
+			executor.WaitForAction(ctx)
+		}
+		Expect(time.Since(start)).To(BeNumerically(">=", 250*time.Millisecond))
+	})
+})
@@ -16,6 +16,17 @@ limitations under the License.
 
 package executor
 
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	lua "github.com/yuin/gopher-lua"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
 var (
 	FetchResources          = fetchResources
 	GetMatchingResources    = getMatchingResources
@@ -26,6 +37,21 @@ var (
 	Transform               = transform
 	AggregatedSelection     = aggregatedSelection
 	GetNamespaces           = getNamespaces
+	PassesFilters           = passesFilters
+	MatchesFieldSelector    = matchesFieldSelector
+	ProtectedByAnnotation   = protectedByAnnotation
+	DeleteResources         = deleteResources
+	ScheduleDeletions       = scheduleDeletions
+	GetCleanerConfigMapData = getCleanerConfigMapData
+	ScaleMatchingResources  = scaleMatchingResources
+	EvictMatchingResources  = evictMatchingResources
+	DrainMatchingResources  = drainMatchingResources
+	LabelMatchingResources  = labelMatchingResources
+	RunActionSteps          = runActionSteps
+	VisitWithOptions        = visitWithOptions
+	DeferResources          = deferResources
+	WithActionPacer         = withActionPacer
+	WaitForAction           = waitForAction
 
 	FetchEvents            = fetchEvents
 	FetchPodLogs           = fetchPodLogs
@@ -39,6 +65,10 @@ var (
 	UpdateRegistry             = updateRegistry
 
 	CheckBlastRadiusLimit = checkBlastRadiusLimit
+
+	UpdateMatchingResources = updateMatchingResources
+	ComputeDiff             = computeDiff
+	RunTransform            = runTransform
 )
 
 // ContainerLogTails is an alias for the unexported containerLogTails, so
@@ -48,12 +78,42 @@ type ContainerLogTails = containerLogTails
 var (
 	GenerateReportSpec      = generateReportSpec
 	AddRollbackResourceData = addRollbackResourceData
-	ValidateRollbackConfig  = validateRollbackConfig
 	PersistRollbackSnapshot = persistRollbackSnapshot
 )
 
 const MaxRollbackResourceSize = maxRollbackResourceSize
 
+var (
+	GenerateExecutionRecord = generateExecutionRecord
+	RecordExecution         = recordExecution
+)
+
+const MaxExecutionRecordResources = maxExecutionRecordResources
+
+const (
+	DeletionMarksSuffix = deletionMarksSuffix
+	RescuedMark         = rescuedMark
+)
+
+// DeletionMarkDeleteAfter returns the deadline, or RescuedMark, recorded in a
+// deletion-marks ConfigMap value.
+func DeletionMarkDeleteAfter(value string) string {
+	return decodeDeletionMark(value).DeleteAfter
+}
+
+func NewRunStats(startTime time.Time, scanned, matched int, processed, failed []ResourceResult,
+	blastRadiusExceeded bool) *runStats {
+
+	return &runStats{
+		startTime:           startTime,
+		scanned:             scanned,
+		matched:             matched,
+		processed:           processed,
+		failed:              failed,
+		blastRadiusExceeded: blastRadiusExceeded,
+	}
+}
+
 var (
 	BuildSlackAttachment         = buildSlackAttachment
 	BuildTeamsCard               = buildTeamsCard
@@ -89,6 +149,7 @@ func (m *Manager) ClearInternalStruct() {
 	m.inProgress = make([]string, 0)
 	m.jobQueue = make([]string, 0)
 	m.results = make(map[string]error)
+	m.runStats = make(map[string]*runStats)
 }
 
 func (m *Manager) SetInProgress(inProgress []string) {
@@ -123,6 +184,19 @@ func (m *Manager) GetResults() map[string]error {
 	return m.results
 }
 
+func SetRunStatsEndTime(stats *runStats, endTime time.Time) {
+	stats.endTime = endTime
+}
+
+func SetRunStatsSkipped(stats *runStats, skipped, deferred []ResourceResult) {
+	stats.skipped = skipped
+	stats.deferred = deferred
+}
+
+func (m *Manager) SetRunStats(cleanerName string, stats *runStats) {
+	m.runStats[cleanerName] = stats
+}
+
 func GetWebexRoom(info *webexInfo) string {
 	return info.room
 }
@@ -136,3 +210,57 @@ func GetSlackChannelID(info *slackInfo) string {
 func GetSlackToken(info *slackInfo) string {
 	return info.token
 }
+
+var NewLookupCache = newLookupCache
+
+var TrackCachedResources = trackCachedResources
+
+// IsInformerRunning returns true if an informer is running for gvr.
+func IsInformerRunning(gvr schema.GroupVersionResource) bool {
+	informers.mu.Lock()
+	defer informers.mu.Unlock()
+	_, ok := informers.informers[gvr]
+	return ok
+}
+
+var WebhookMatchingResources = webhookMatchingResources
+
+// SetWebhookRetryBackoff sets the wait before the first retry of a failed
+// webhook request, returning the previous one.
+func SetWebhookRetryBackoff(backoff time.Duration) time.Duration {
+	previous := webhookRetryBackoff
+	webhookRetryBackoff = backoff
+	return previous
+}
+
+// SetK8sClient sets the client the executor uses, returning the previous one.
+func SetK8sClient(c client.Client) client.Client {
+	previous := k8sClient
+	k8sClient = c
+	return previous
+}
+
+var NewLuaSandboxPool = newLuaSandboxPool
+
+// IsMatchWithPool runs the evaluate function script on resource with a Lua
+// state taken from pool.
+func IsMatchWithPool(ctx context.Context, resource *unstructured.Unstructured, script string,
+	pool *luaSandboxPool) (bool, error) {
+
+	result, err := runEvaluateWithGlobals(ctx, resource, script, nil, nil, pool, func(l *lua.LState) {
+		setEvaluateGlobals(l, nil, nil, nil, nil)
+	}, logr.Discard())
+	if err != nil {
+		return false, err
+	}
+	return result.Matching, nil
+}
+
+// CompiledLuaScripts returns the generation and sources the scripts of
+// Cleaner cleanerName were compiled for.
+func CompiledLuaScripts(cleanerName string) (generation int64, sources []string, ok bool) {
+	luaScripts.mu.RLock()
+	defer luaScripts.mu.RUnlock()
+	scripts, ok := luaScripts.cleaners[cleanerName]
+	return scripts.generation, scripts.sources, ok
+}
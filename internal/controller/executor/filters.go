@@ -0,0 +1,129 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+)
+
+// passesFilters returns true if resource satisfies the OlderThan and
+// OwnerFilter of sr. Owners are fetched through lookups.
+func passesFilters(ctx context.Context, sr *appsv1alpha1.ResourceSelector,
+	resource *unstructured.Unstructured, lookups *lookupCache) (bool, error) {
+
+	if sr.OlderThan != nil {
+		created := resource.GetCreationTimestamp()
+		if time.Since(created.Time) <= sr.OlderThan.Duration {
+			return false, nil
+		}
+	}
+
+	if sr.OwnerFilter != nil {
+		return passesOwnerFilter(ctx, sr.OwnerFilter, resource, lookups)
+	}
+
+	return true, nil
+}
+
+func passesOwnerFilter(ctx context.Context, filter *appsv1alpha1.OwnerFilter,
+	resource *unstructured.Unstructured, lookups *lookupCache) (bool, error) {
+
+	owners := resource.GetOwnerReferences()
+	if filter.HasOwner != nil && *filter.HasOwner != (len(owners) > 0) {
+		return false, nil
+	}
+
+	if filter.Kind != "" {
+		filtered := make([]metav1.OwnerReference, 0, len(owners))
+		for i := range owners {
+			if owners[i].Kind == filter.Kind {
+				filtered = append(filtered, owners[i])
+			}
+		}
+		if len(filtered) == 0 {
+			return false, nil
+		}
+		owners = filtered
+	}
+
+	if !filter.OwnerMissing {
+		return true, nil
+	}
+
+	for i := range owners {
+		missing, err := isOwnerMissing(ctx, resource, &owners[i], lookups)
+		if err != nil {
+			return false, err
+		}
+		if missing {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// isOwnerMissing returns true if the owner referenced by ref no longer exists,
+// or has been replaced by a different object with the same name.
+func isOwnerMissing(ctx context.Context, resource *unstructured.Unstructured, ref *metav1.OwnerReference,
+	lookups *lookupCache) (bool, error) {
+
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return false, err
+	}
+
+	// Owners are either in the same namespace as resource or cluster-scoped,
+	// in which case the namespace is ignored.
+	owner, err := lookups.get(ctx, gv.WithKind(ref.Kind), resource.GetNamespace(), ref.Name)
+	if err != nil {
+		return false, err
+	}
+
+	return owner == nil || owner.GetUID() != ref.UID, nil
+}
+
+// matchesFieldSelector returns true if resource matches selector. It is used
+// where the API server cannot apply the selector, like when reading from the
+// informer cache. Non-string fields are compared using their string format.
+func matchesFieldSelector(selector fields.Selector, resource *unstructured.Unstructured) bool {
+	if selector.Empty() {
+		return true
+	}
+
+	set := fields.Set{}
+	for _, requirement := range selector.Requirements() {
+		value, found, err := unstructured.NestedFieldNoCopy(resource.Object,
+			strings.Split(requirement.Field, ".")...)
+		if err != nil || !found || value == nil {
+			continue
+		}
+		set[requirement.Field] = fmt.Sprint(value)
+	}
+
+	return selector.Matches(set)
+}
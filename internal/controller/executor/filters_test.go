@@ -0,0 +1,140 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+	"gianlucam76/k8s-cleaner/internal/controller/executor"
+)
+
+var _ = Describe("ResourceSelector filters", func() {
+	var ns *corev1.Namespace
+
+	BeforeEach(func() {
+		ns = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString()},
+		}
+		Expect(k8sClient.Create(context.TODO(), ns)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, ns)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), ns)).To(Succeed())
+	})
+
+	toUnstructured := func(obj runtime.Object) *unstructured.Unstructured {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		Expect(err).To(BeNil())
+		return &unstructured.Unstructured{Object: content}
+	}
+
+	ownedConfigMap := func(owners ...metav1.OwnerReference) *unstructured.Unstructured {
+		return toUnstructured(&corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{APIVersion: apiVersionV1, Kind: kindConfigMap},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         ns.Name,
+				Name:              randomString(),
+				OwnerReferences:   owners,
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+			},
+		})
+	}
+
+	It("filters resources by age", func() {
+		resource := ownedConfigMap()
+		sr := &appsv1alpha1.ResourceSelector{OlderThan: &metav1.Duration{Duration: 30 * time.Minute}}
+
+		passes, err := executor.PassesFilters(context.TODO(), sr, resource, executor.NewLookupCache(k8sClient))
+		Expect(err).To(BeNil())
+		Expect(passes).To(BeTrue())
+
+		sr.OlderThan.Duration = 2 * time.Hour
+		passes, err = executor.PassesFilters(context.TODO(), sr, resource, executor.NewLookupCache(k8sClient))
+		Expect(err).To(BeNil())
+		Expect(passes).To(BeFalse())
+	})
+
+	It("filters resources by owner presence and kind", func() {
+		owner := metav1.OwnerReference{APIVersion: apiVersionV1, Kind: kindSecret, Name: randomString(),
+			UID: "uid"}
+		orphan := ownedConfigMap()
+		owned := ownedConfigMap(owner)
+
+		sr := &appsv1alpha1.ResourceSelector{OwnerFilter: &appsv1alpha1.OwnerFilter{HasOwner: ptr.To(false)}}
+		lookups := executor.NewLookupCache(k8sClient)
+		Expect(executor.PassesFilters(context.TODO(), sr, orphan, lookups)).To(BeTrue())
+		Expect(executor.PassesFilters(context.TODO(), sr, owned, lookups)).To(BeFalse())
+
+		sr.OwnerFilter = &appsv1alpha1.OwnerFilter{Kind: kindSecret}
+		Expect(executor.PassesFilters(context.TODO(), sr, orphan, lookups)).To(BeFalse())
+		Expect(executor.PassesFilters(context.TODO(), sr, owned, lookups)).To(BeTrue())
+
+		sr.OwnerFilter = &appsv1alpha1.OwnerFilter{Kind: kindConfigMap}
+		Expect(executor.PassesFilters(context.TODO(), sr, owned, lookups)).To(BeFalse())
+	})
+
+	It("filters resources whose owner is missing from the cluster", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name, Name: randomString()},
+		}
+		Expect(k8sClient.Create(context.TODO(), secret)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, secret)).To(Succeed())
+
+		existing := ownedConfigMap(metav1.OwnerReference{APIVersion: apiVersionV1, Kind: kindSecret,
+			Name: secret.Name, UID: secret.UID})
+		missing := ownedConfigMap(metav1.OwnerReference{APIVersion: apiVersionV1, Kind: kindSecret,
+			Name: randomString(), UID: "uid"})
+		replaced := ownedConfigMap(metav1.OwnerReference{APIVersion: apiVersionV1, Kind: kindSecret,
+			Name: secret.Name, UID: "old-uid"})
+
+		sr := &appsv1alpha1.ResourceSelector{OwnerFilter: &appsv1alpha1.OwnerFilter{OwnerMissing: true}}
+		lookups := executor.NewLookupCache(k8sClient)
+		Expect(executor.PassesFilters(context.TODO(), sr, existing, lookups)).To(BeFalse())
+		Expect(executor.PassesFilters(context.TODO(), sr, missing, lookups)).To(BeTrue())
+		Expect(executor.PassesFilters(context.TODO(), sr, replaced, lookups)).To(BeTrue())
+	})
+
+	It("matchesFieldSelector evaluates field selectors on unstructured resources", func() {
+		pod := toUnstructured(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name, Name: randomString()},
+			Status:     corev1.PodStatus{Phase: corev1.PodFailed},
+		})
+
+		selector, err := fields.ParseSelector("status.phase=Failed")
+		Expect(err).To(BeNil())
+		Expect(executor.MatchesFieldSelector(selector, pod)).To(BeTrue())
+
+		selector, err = fields.ParseSelector("status.phase!=Failed,metadata.namespace=" + ns.Name)
+		Expect(err).To(BeNil())
+		Expect(executor.MatchesFieldSelector(selector, pod)).To(BeFalse())
+
+		Expect(executor.MatchesFieldSelector(fields.Everything(), pod)).To(BeTrue())
+	})
+})
@@ -0,0 +1,275 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/cache"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+)
+
+const (
+	// informerSyncTimeout bounds how long a run waits for a newly started
+	// informer to sync. Past it, the run lists from the API server instead.
+	informerSyncTimeout = 2 * time.Minute
+)
+
+var (
+	mapperLock sync.Mutex
+	// restMapper is shared by all runs. It is built lazily from discovery and
+	// reset whenever a kind is not found, so newly installed CRDs are picked up.
+	restMapper *restmapper.DeferredDiscoveryRESTMapper
+)
+
+// getResourceForKind returns the GroupVersionResource of gvk. The returned
+// error satisfies meta.IsNoMatchError if the kind is not served.
+func getResourceForKind(cfg *rest.Config, gvk schema.GroupVersionKind) (schema.GroupVersionResource, error) {
+	mapperLock.Lock()
+	if restMapper == nil {
+		dc, err := discovery.NewDiscoveryClientForConfig(cfg)
+		if err != nil {
+			mapperLock.Unlock()
+			return schema.GroupVersionResource{}, err
+		}
+		restMapper = restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(dc))
+	}
+	mapper := restMapper
+	mapperLock.Unlock()
+
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		// The kind might have been installed after discovery was cached.
+		mapper.Reset()
+		mapping, err = mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+
+	return mapping.Resource, nil
+}
+
+// informerCache holds one dynamic informer per GroupVersionResource, shared by
+// all Cleaners with a ResourceSelector setting UseCache. Informers are started
+// when the first Cleaner needs them and stopped when the last one releases them.
+type informerCache struct {
+	ctx    context.Context
+	client dynamic.Interface
+
+	mu        sync.Mutex
+	informers map[schema.GroupVersionResource]*gvrInformer
+	// usage contains, per Cleaner name, the resources it reads from the cache.
+	usage map[string]map[schema.GroupVersionResource]bool
+}
+
+type gvrInformer struct {
+	informer cache.SharedIndexInformer
+	cancel   context.CancelFunc
+	users    map[string]bool
+}
+
+// informers is the cache used by all Cleaner runs. It is nil until
+// InitializeClient is called.
+var informers *informerCache
+
+func newInformerCache(ctx context.Context, cfg *rest.Config) (*informerCache, error) {
+	d, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &informerCache{
+		ctx:       ctx,
+		client:    d,
+		informers: make(map[schema.GroupVersionResource]*gvrInformer),
+		usage:     make(map[string]map[schema.GroupVersionResource]bool),
+	}, nil
+}
+
+// trackCachedResources records which resources cleaner reads from the cache,
+// starting informers it is the first to need and stopping the ones it was
+// the last to use. It then waits, up to informerSyncTimeout, for the
+// informers cleaner needs to be synced.
+func trackCachedResources(ctx context.Context, cleaner *appsv1alpha1.Cleaner, logger logr.Logger) {
+	if informers == nil {
+		return
+	}
+
+	gvrs := make(map[schema.GroupVersionResource]bool)
+	for i := range cleaner.Spec.ResourcePolicySet.ResourceSelectors {
+		sr := &cleaner.Spec.ResourcePolicySet.ResourceSelectors[i]
+		if !sr.UseCache {
+			continue
+		}
+		gvk := schema.GroupVersionKind{Group: sr.Group, Version: sr.Version, Kind: sr.Kind}
+		gvr, err := getResourceForKind(config, gvk)
+		if err != nil {
+			// fetchResources reports (or ignores) this when listing.
+			continue
+		}
+		gvrs[gvr] = true
+	}
+
+	synced := informers.setUsage(cleaner.Name, gvrs, logger)
+
+	ctx, cancel := context.WithTimeout(ctx, informerSyncTimeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(ctx.Done(), synced...) {
+		logger.V(logs.LogInfo).Info("informer cache not synced yet, listing from the API server")
+	}
+}
+
+// setUsage replaces the set of resources cleanerName reads from the cache and
+// returns the HasSynced functions of the corresponding informers.
+func (ic *informerCache) setUsage(cleanerName string, gvrs map[schema.GroupVersionResource]bool,
+	logger logr.Logger) []cache.InformerSynced {
+
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	for gvr := range ic.usage[cleanerName] {
+		if !gvrs[gvr] {
+			ic.releaseLocked(cleanerName, gvr, logger)
+		}
+	}
+
+	synced := make([]cache.InformerSynced, 0, len(gvrs))
+	for gvr := range gvrs {
+		gi, ok := ic.informers[gvr]
+		if !ok {
+			gi = ic.startLocked(gvr, logger)
+		}
+		gi.users[cleanerName] = true
+		synced = append(synced, gi.informer.HasSynced)
+	}
+
+	if len(gvrs) == 0 {
+		delete(ic.usage, cleanerName)
+	} else {
+		ic.usage[cleanerName] = gvrs
+	}
+
+	return synced
+}
+
+func (ic *informerCache) startLocked(gvr schema.GroupVersionResource, logger logr.Logger) *gvrInformer {
+	logger.V(logs.LogInfo).Info(fmt.Sprintf("starting informer for %s", gvr.String()))
+
+	informer := dynamicinformer.NewFilteredDynamicInformer(ic.client, gvr, "", 0,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, nil).Informer()
+
+	ctx, cancel := context.WithCancel(ic.ctx)
+	go informer.Run(ctx.Done())
+
+	gi := &gvrInformer{informer: informer, cancel: cancel, users: make(map[string]bool)}
+	ic.informers[gvr] = gi
+	return gi
+}
+
+func (ic *informerCache) releaseLocked(cleanerName string, gvr schema.GroupVersionResource,
+	logger logr.Logger) {
+
+	gi, ok := ic.informers[gvr]
+	if !ok {
+		return
+	}
+	delete(gi.users, cleanerName)
+	if len(gi.users) == 0 {
+		logger.V(logs.LogInfo).Info(fmt.Sprintf("stopping informer for %s", gvr.String()))
+		gi.cancel()
+		delete(ic.informers, gvr)
+	}
+}
+
+// informer returns the informer for gvr, nil if it is not running.
+func (ic *informerCache) informer(gvr schema.GroupVersionResource) cache.SharedIndexInformer {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	gi, ok := ic.informers[gvr]
+	if !ok {
+		return nil
+	}
+	return gi.informer
+}
+
+// list returns the resources of type gvr in namespaces (all namespaces if
+// empty) matching selector. ok is false if there is no synced informer for gvr,
+// in which case the caller should list from the API server.
+func (ic *informerCache) list(gvr schema.GroupVersionResource, namespaces []string,
+	selector labels.Selector) (result []unstructured.Unstructured, ok bool, err error) {
+
+	ic.mu.Lock()
+	gi, ok := ic.informers[gvr]
+	ic.mu.Unlock()
+	if !ok || !gi.informer.HasSynced() {
+		return nil, false, nil
+	}
+
+	var objects []interface{}
+	indexer := gi.informer.GetIndexer()
+	if len(namespaces) == 0 {
+		objects = indexer.List()
+	} else {
+		for i := range namespaces {
+			tmp, err := indexer.ByIndex(cache.NamespaceIndex, namespaces[i])
+			if err != nil {
+				return nil, true, err
+			}
+			objects = append(objects, tmp...)
+		}
+	}
+
+	result = make([]unstructured.Unstructured, 0, len(objects))
+	for i := range objects {
+		u, isUnstructured := objects[i].(*unstructured.Unstructured)
+		if !isUnstructured || !selector.Matches(labels.Set(u.GetLabels())) {
+			continue
+		}
+		// Objects in the cache are shared: hand out copies.
+		result = append(result, *u.DeepCopy())
+	}
+
+	return result, true, nil
+}
+
+// ReleaseInformers stops the informers only cleanerName was reading from.
+// It is called when a Cleaner is deleted.
+func ReleaseInformers(cleanerName string, logger logr.Logger) {
+	if informers == nil {
+		return
+	}
+	informers.setUsage(cleanerName, nil, logger)
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor_test
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+	"gianlucam76/k8s-cleaner/internal/controller/executor"
+
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+)
+
+var _ = Describe("Informer cache", func() {
+	var ns *corev1.Namespace
+
+	configMapGVR := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+	BeforeEach(func() {
+		ns = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString()},
+		}
+		Expect(k8sClient.Create(context.TODO(), ns)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, ns)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), ns)).To(Succeed())
+	})
+
+	newCleaner := func(selector *appsv1alpha1.ResourceSelector) *appsv1alpha1.Cleaner {
+		return &appsv1alpha1.Cleaner{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString()},
+			Spec: appsv1alpha1.CleanerSpec{
+				Schedule: "* * * * *",
+				Action:   appsv1alpha1.ActionScan,
+				ResourcePolicySet: appsv1alpha1.ResourcePolicySet{
+					ResourceSelectors: []appsv1alpha1.ResourceSelector{*selector},
+				},
+			},
+		}
+	}
+
+	It("fetchResources reads from the informer cache when UseCache is set", func() {
+		for _, app := range []string{"web", "db"} {
+			cm := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: ns.Name,
+					Name:      randomString(),
+					Labels:    map[string]string{"app": app},
+				},
+			}
+			Expect(k8sClient.Create(context.TODO(), cm)).To(Succeed())
+			Expect(waitForObject(context.TODO(), k8sClient, cm)).To(Succeed())
+		}
+
+		selector := &appsv1alpha1.ResourceSelector{
+			Namespace: ns.Name,
+			Kind:      kindConfigMap,
+			Version:   apiVersionV1,
+			UseCache:  true,
+			LabelFilters: []libsveltosv1beta1.LabelFilter{
+				{Key: "app", Operation: libsveltosv1beta1.OperationEqual, Value: "web"},
+			},
+		}
+		cleaner := newCleaner(selector)
+
+		executor.TrackCachedResources(context.TODO(), cleaner, logr.Discard())
+		Expect(executor.IsInformerRunning(configMapGVR)).To(BeTrue())
+
+		Eventually(func() int {
+			resources, err := executor.FetchResources(context.TODO(), selector, logr.Discard())
+			if err != nil {
+				return -1
+			}
+			return len(resources)
+		}, timeout, pollingInterval).Should(Equal(1))
+
+		executor.ReleaseInformers(cleaner.Name, logr.Discard())
+		Expect(executor.IsInformerRunning(configMapGVR)).To(BeFalse())
+	})
+
+	It("stops an informer only when the last Cleaner using it releases it", func() {
+		selector := &appsv1alpha1.ResourceSelector{
+			Namespace: ns.Name,
+			Kind:      kindConfigMap,
+			Version:   apiVersionV1,
+			UseCache:  true,
+		}
+		cleaner1 := newCleaner(selector)
+		cleaner2 := newCleaner(selector)
+
+		executor.TrackCachedResources(context.TODO(), cleaner1, logr.Discard())
+		executor.TrackCachedResources(context.TODO(), cleaner2, logr.Discard())
+		Expect(executor.IsInformerRunning(configMapGVR)).To(BeTrue())
+
+		By("no longer using the cache, cleaner1 releases the informer")
+		cleaner1.Spec.ResourcePolicySet.ResourceSelectors[0].UseCache = false
+		executor.TrackCachedResources(context.TODO(), cleaner1, logr.Discard())
+		Expect(executor.IsInformerRunning(configMapGVR)).To(BeTrue())
+
+		executor.ReleaseInformers(cleaner2.Name, logr.Discard())
+		Expect(executor.IsInformerRunning(configMapGVR)).To(BeFalse())
+	})
+})
@@ -0,0 +1,195 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"text/template"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+)
+
+// labelTemplateData is what values of added labels and annotations are
+// executed with.
+type labelTemplateData struct {
+	Message   string
+	Name      string
+	Namespace string
+	Kind      string
+}
+
+// labelTemplates are the parsed values of the labels and annotations a Label
+// action adds, by key.
+type labelTemplates struct {
+	labels      map[string]*template.Template
+	annotations map[string]*template.Template
+}
+
+// labelMatchingResources adds and removes labels and annotations of resources
+// as configured by labelOptions, sending a JSON merge patch per resource.
+func labelMatchingResources(ctx context.Context, cleanerName string, resources []ResourceResult,
+	labelOptions *appsv1alpha1.LabelOptions, dryRun bool, logger logr.Logger,
+) (processedResources, failedResources, skippedResources []ResourceResult, err error) {
+
+	processedResources = make([]ResourceResult, 0)
+	var failedActions []error // Slice to store all errors
+
+	resources, skippedResources = skipProtectedResources(ctx, cleanerName, resources, logger)
+
+	if labelOptions == nil {
+		return processedResources, nil, skippedResources,
+			errors.New("labelOptions must be set when action is Label")
+	}
+
+	templates, err := parseLabelTemplates(labelOptions)
+	if err != nil {
+		return processedResources, nil, skippedResources, err
+	}
+
+	if !dryRun {
+		reportUpdatedCount(cleanerName, float64(len(resources)))
+	}
+
+	numberOfErrors := 0
+	for i := range resources {
+		resource := resources[i]
+		l := logger.WithValues("resource", fmt.Sprintf("%s:%s/%s",
+			resource.Resource.GetKind(),
+			resource.Resource.GetNamespace(),
+			resource.Resource.GetName()))
+		waitForAction(ctx)
+		l.Info("labeling resource")
+
+		var patch []byte
+		patch, err = buildLabelPatch(&resource, labelOptions, templates)
+		if err == nil {
+			err = k8sClient.Patch(ctx, resource.Resource, client.RawPatch(types.MergePatchType, patch),
+				&client.PatchOptions{DryRun: dryRunOption(dryRun)})
+		}
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			numberOfErrors++
+			reportErrorEvent(cleanerName, resource.Resource.GetAPIVersion(),
+				resource.Resource.GetKind())
+			l.Info(fmt.Sprintf("failed to label resource: %v", err))
+			failedActions = append(failedActions, fmt.Errorf("%s %s/%s: %w", resource.Resource.GetKind(),
+				resource.Resource.GetNamespace(), resource.Resource.GetName(), err))
+			failedResources = append(failedResources, ResourceResult{Resource: resource.Resource,
+				Message: err.Error()})
+			continue
+		}
+
+		processedResources = append(processedResources, resource)
+		if !dryRun {
+			reportUpdateEvent(cleanerName, resource.Resource.GetAPIVersion(),
+				resource.Resource.GetKind())
+		}
+	}
+
+	if len(failedActions) > 0 {
+		// Use errors.Join to combine all collected errors into a single error
+		return processedResources, failedResources, skippedResources, errors.Join(failedActions...)
+	}
+
+	reportErrorCount(cleanerName, float64(numberOfErrors))
+
+	return processedResources, failedResources, skippedResources, nil
+}
+
+// parseLabelTemplates parses the values of the labels and annotations
+// labelOptions adds.
+func parseLabelTemplates(labelOptions *appsv1alpha1.LabelOptions) (*labelTemplates, error) {
+	parse := func(kind string, values map[string]string) (map[string]*template.Template, error) {
+		templates := make(map[string]*template.Template, len(values))
+		for key, value := range values {
+			tmpl, err := template.New(key).Option("missingkey=error").Parse(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid template for %s %s: %w", kind, key, err)
+			}
+			templates[key] = tmpl
+		}
+		return templates, nil
+	}
+
+	labels, err := parse("label", labelOptions.AddLabels)
+	if err != nil {
+		return nil, err
+	}
+	annotations, err := parse("annotation", labelOptions.AddAnnotations)
+	if err != nil {
+		return nil, err
+	}
+
+	return &labelTemplates{labels: labels, annotations: annotations}, nil
+}
+
+// buildLabelPatch returns the JSON merge patch adding and removing the labels
+// and annotations of resource. Removed keys are set to null.
+func buildLabelPatch(resource *ResourceResult, labelOptions *appsv1alpha1.LabelOptions,
+	templates *labelTemplates) ([]byte, error) {
+
+	data := labelTemplateData{
+		Message:   resource.Message,
+		Name:      resource.Resource.GetName(),
+		Namespace: resource.Resource.GetNamespace(),
+		Kind:      resource.Resource.GetKind(),
+	}
+
+	build := func(remove []string, add map[string]*template.Template) (map[string]interface{}, error) {
+		values := make(map[string]interface{}, len(remove)+len(add))
+		for i := range remove {
+			values[remove[i]] = nil
+		}
+		for key, tmpl := range add {
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, data); err != nil {
+				return nil, err
+			}
+			values[key] = buf.String()
+		}
+		return values, nil
+	}
+
+	metadata := make(map[string]interface{})
+	labels, err := build(labelOptions.RemoveLabels, templates.labels)
+	if err != nil {
+		return nil, err
+	}
+	if len(labels) > 0 {
+		metadata["labels"] = labels
+	}
+	annotations, err := build(labelOptions.RemoveAnnotations, templates.annotations)
+	if err != nil {
+		return nil, err
+	}
+	if len(annotations) > 0 {
+		metadata["annotations"] = annotations
+	}
+
+	return json.Marshal(map[string]interface{}{"metadata": metadata})
+}
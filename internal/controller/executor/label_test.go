@@ -0,0 +1,131 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor_test
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+	"gianlucam76/k8s-cleaner/internal/controller/executor"
+)
+
+var _ = Describe("Label", func() {
+	var ns *corev1.Namespace
+
+	BeforeEach(func() {
+		ns = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString()},
+		}
+		Expect(k8sClient.Create(context.TODO(), ns)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, ns)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), ns)).To(Succeed())
+	})
+
+	createConfigMap := func(message string) executor.ResourceResult {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   ns.Name,
+				Name:        randomString(),
+				Labels:      map[string]string{"stale": "false", "app": "web"},
+				Annotations: map[string]string{"owner": "team-a"},
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), cm)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, cm)).To(Succeed())
+
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cm)
+		Expect(err).To(BeNil())
+		u := &unstructured.Unstructured{Object: content}
+		u.SetAPIVersion("v1")
+		u.SetKind("ConfigMap")
+		return executor.ResourceResult{Resource: u, Message: message}
+	}
+
+	getConfigMap := func(resource executor.ResourceResult) *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(context.TODO(), types.NamespacedName{
+			Namespace: resource.Resource.GetNamespace(), Name: resource.Resource.GetName()}, cm)).To(Succeed())
+		return cm
+	}
+
+	It("adds and removes labels and annotations, rendering templates", func() {
+		resource := createConfigMap("not mounted by any pod")
+
+		processed, failed, skipped, err := executor.LabelMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{resource}, &appsv1alpha1.LabelOptions{
+				AddLabels:         map[string]string{"stale": "true"},
+				RemoveLabels:      []string{"app"},
+				AddAnnotations:    map[string]string{"cleaner/reason": "{{ .Kind }} {{ .Name }}: {{ .Message }}"},
+				RemoveAnnotations: []string{"owner"},
+			}, false, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(failed).To(BeEmpty())
+		Expect(skipped).To(BeEmpty())
+		Expect(processed).To(HaveLen(1))
+
+		cm := getConfigMap(resource)
+		Expect(cm.Labels).To(Equal(map[string]string{"stale": "true"}))
+		Expect(cm.Annotations).To(Equal(map[string]string{
+			"cleaner/reason": "ConfigMap " + cm.Name + ": not mounted by any pod",
+		}))
+	})
+
+	It("leaves resources untouched in dry run", func() {
+		resource := createConfigMap("")
+
+		processed, _, _, err := executor.LabelMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{resource}, &appsv1alpha1.LabelOptions{
+				AddLabels: map[string]string{"stale": "true"},
+			}, true, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(processed).To(HaveLen(1))
+
+		Expect(getConfigMap(resource).Labels["stale"]).To(Equal("false"))
+	})
+
+	It("fails on invalid templates", func() {
+		resource := createConfigMap("")
+
+		processed, _, _, err := executor.LabelMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{resource}, &appsv1alpha1.LabelOptions{
+				AddAnnotations: map[string]string{"cleaner/reason": "{{ .Message "},
+			}, false, logr.Discard())
+		Expect(err).ToNot(BeNil())
+		Expect(processed).To(BeEmpty())
+
+		processed, failed, _, err := executor.LabelMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{resource}, &appsv1alpha1.LabelOptions{
+				AddAnnotations: map[string]string{"cleaner/reason": "{{ .Unknown }}"},
+			}, false, logr.Discard())
+		Expect(err).ToNot(BeNil())
+		Expect(processed).To(BeEmpty())
+		Expect(failed).To(HaveLen(1))
+	})
+})
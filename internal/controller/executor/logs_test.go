@@ -155,7 +155,7 @@ function evaluate(obj)
   return hs
 end`
 
-		matching, _, err := executor.IsMatch(resource, script, nil, nil, current, nil, logr.Logger{})
+		matching, _, err := executor.IsMatch(context.TODO(), resource, script, nil, nil, nil, nil, current, nil, logr.Logger{})
 		Expect(err).ToNot(HaveOccurred())
 		Expect(matching).To(BeTrue())
 	})
@@ -173,7 +173,7 @@ function evaluate(obj)
   return hs
 end`
 
-		matching, _, err := executor.IsMatch(resource, script, nil, nil, current, nil, logr.Logger{})
+		matching, _, err := executor.IsMatch(context.TODO(), resource, script, nil, nil, nil, nil, current, nil, logr.Logger{})
 		Expect(err).ToNot(HaveOccurred())
 		Expect(matching).To(BeTrue())
 	})
@@ -186,7 +186,7 @@ function evaluate(obj)
   return hs
 end`
 
-		matching, _, err := executor.IsMatch(resource, script, nil, nil, nil, nil, logr.Logger{})
+		matching, _, err := executor.IsMatch(context.TODO(), resource, script, nil, nil, nil, nil, nil, nil, logr.Logger{})
 		Expect(err).ToNot(HaveOccurred())
 		Expect(matching).To(BeTrue())
 	})
@@ -203,7 +203,7 @@ function evaluate(obj)
   return hs
 end`
 
-		matching, _, err := executor.IsMatch(resource, script, nil, nil, current, previous, logr.Logger{})
+		matching, _, err := executor.IsMatch(context.TODO(), resource, script, nil, nil, nil, nil, current, previous, logr.Logger{})
 		Expect(err).ToNot(HaveOccurred())
 		Expect(matching).To(BeTrue())
 	})
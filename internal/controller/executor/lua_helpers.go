@@ -0,0 +1,400 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	lua "github.com/yuin/gopher-lua"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/yaml"
+)
+
+// luaHelpersModuleName is the name scripts pass to require to load the
+// helpers, e.g. local cleaner = require("cleaner").
+const luaHelpersModuleName = "cleaner"
+
+// luaHelpers are the functions exposed by the "cleaner" module. Functions
+// parsing their input return nil and an error message when the input is
+// invalid, so scripts can decide whether to skip the resource or fail.
+var luaHelpers = map[string]lua.LGFunction{
+	"now":             luaNow,
+	"parseTime":       luaParseTime,
+	"formatTime":      luaFormatTime,
+	"age":             luaAge,
+	"parseDuration":   luaParseDuration,
+	"semverCompare":   luaSemverCompare,
+	"semverSatisfies": luaSemverSatisfies,
+	"regexMatch":      luaRegexMatch,
+	"regexFind":       luaRegexFind,
+	"regexReplace":    luaRegexReplace,
+	"toJSON":          luaToJSON,
+	"fromJSON":        luaFromJSON,
+	"toYAML":          luaToYAML,
+	"fromYAML":        luaFromYAML,
+	"matchLabels":     luaMatchLabels,
+	"parseQuantity":   luaParseQuantity,
+	"compareQuantity": luaCompareQuantity,
+	"containers":      luaContainers,
+}
+
+// loadLuaHelpers is the package.preload loader of the "cleaner" module.
+func loadLuaHelpers(l *lua.LState) int {
+	l.Push(l.SetFuncs(l.NewTable(), luaHelpers))
+	return 1
+}
+
+// luaFail pushes the nil, message pair returned by helpers on invalid input.
+func luaFail(l *lua.LState, err error) int {
+	l.Push(lua.LNil)
+	l.Push(lua.LString(err.Error()))
+	return 2
+}
+
+// luaNow returns the current time in seconds since the epoch.
+func luaNow(l *lua.LState) int {
+	l.Push(lua.LNumber(time.Now().Unix()))
+	return 1
+}
+
+// luaParseTime converts an RFC3339 timestamp, the format used by Kubernetes
+// for fields like metadata.creationTimestamp, to seconds since the epoch.
+func luaParseTime(l *lua.LState) int {
+	t, err := time.Parse(time.RFC3339, l.CheckString(1))
+	if err != nil {
+		return luaFail(l, err)
+	}
+	l.Push(lua.LNumber(t.Unix()))
+	return 1
+}
+
+// luaFormatTime converts seconds since the epoch to an RFC3339 timestamp.
+func luaFormatTime(l *lua.LState) int {
+	l.Push(lua.LString(time.Unix(l.CheckInt64(1), 0).UTC().Format(time.RFC3339)))
+	return 1
+}
+
+// luaAge returns the seconds elapsed since an RFC3339 timestamp.
+func luaAge(l *lua.LState) int {
+	t, err := time.Parse(time.RFC3339, l.CheckString(1))
+	if err != nil {
+		return luaFail(l, err)
+	}
+	l.Push(lua.LNumber(int64(time.Since(t).Seconds())))
+	return 1
+}
+
+var dayWeekDuration = regexp.MustCompile(`^(\d+)([dw])$`)
+
+// luaParseDuration converts a duration to seconds. On top of the Go duration
+// format ("90s", "1h30m") it accepts whole days and weeks ("7d", "2w"), which
+// are common in TTL annotations.
+func luaParseDuration(l *lua.LState) int {
+	value := l.CheckString(1)
+
+	if m := dayWeekDuration.FindStringSubmatch(value); m != nil {
+		n, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return luaFail(l, err)
+		}
+		unit := int64(24 * 60 * 60)
+		if m[2] == "w" {
+			unit *= 7
+		}
+		l.Push(lua.LNumber(n * unit))
+		return 1
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return luaFail(l, err)
+	}
+	l.Push(lua.LNumber(d.Seconds()))
+	return 1
+}
+
+// luaSemverCompare returns -1, 0 or 1 depending on whether the first version
+// is lower than, equal to or greater than the second one.
+func luaSemverCompare(l *lua.LState) int {
+	v1, err := semver.NewVersion(l.CheckString(1))
+	if err != nil {
+		return luaFail(l, err)
+	}
+	v2, err := semver.NewVersion(l.CheckString(2))
+	if err != nil {
+		return luaFail(l, err)
+	}
+	l.Push(lua.LNumber(v1.Compare(v2)))
+	return 1
+}
+
+// luaSemverSatisfies reports whether a version satisfies a constraint such
+// as ">= 1.2, < 2.0".
+func luaSemverSatisfies(l *lua.LState) int {
+	v, err := semver.NewVersion(l.CheckString(1))
+	if err != nil {
+		return luaFail(l, err)
+	}
+	c, err := semver.NewConstraint(l.CheckString(2))
+	if err != nil {
+		return luaFail(l, err)
+	}
+	l.Push(lua.LBool(c.Check(v)))
+	return 1
+}
+
+// luaRegexMatch reports whether a string contains a match of a Go regular
+// expression.
+func luaRegexMatch(l *lua.LState) int {
+	re, err := regexp.Compile(l.CheckString(1))
+	if err != nil {
+		return luaFail(l, err)
+	}
+	l.Push(lua.LBool(re.MatchString(l.CheckString(2))))
+	return 1
+}
+
+// luaRegexFind returns the leftmost match of a Go regular expression followed
+// by its submatches, or nil if there is no match.
+func luaRegexFind(l *lua.LState) int {
+	re, err := regexp.Compile(l.CheckString(1))
+	if err != nil {
+		return luaFail(l, err)
+	}
+	matches := re.FindStringSubmatch(l.CheckString(2))
+	if matches == nil {
+		l.Push(lua.LNil)
+		return 1
+	}
+	tbl := l.CreateTable(len(matches), 0)
+	for _, m := range matches {
+		tbl.Append(lua.LString(m))
+	}
+	l.Push(tbl)
+	return 1
+}
+
+// luaRegexReplace replaces all matches of a Go regular expression. The
+// replacement can reference submatches with $1, ${name}.
+func luaRegexReplace(l *lua.LState) int {
+	re, err := regexp.Compile(l.CheckString(1))
+	if err != nil {
+		return luaFail(l, err)
+	}
+	l.Push(lua.LString(re.ReplaceAllString(l.CheckString(2), l.CheckString(3))))
+	return 1
+}
+
+func luaToJSON(l *lua.LState) int {
+	data, err := json.Marshal(toGoValue(l.CheckAny(1)))
+	if err != nil {
+		return luaFail(l, err)
+	}
+	l.Push(lua.LString(data))
+	return 1
+}
+
+func luaFromJSON(l *lua.LState) int {
+	var value interface{}
+	if err := json.Unmarshal([]byte(l.CheckString(1)), &value); err != nil {
+		return luaFail(l, err)
+	}
+	l.Push(toLuaValue(l, value))
+	return 1
+}
+
+func luaToYAML(l *lua.LState) int {
+	data, err := yaml.Marshal(toGoValue(l.CheckAny(1)))
+	if err != nil {
+		return luaFail(l, err)
+	}
+	l.Push(lua.LString(data))
+	return 1
+}
+
+func luaFromYAML(l *lua.LState) int {
+	var value interface{}
+	if err := yaml.Unmarshal([]byte(l.CheckString(1)), &value); err != nil {
+		return luaFail(l, err)
+	}
+	l.Push(toLuaValue(l, value))
+	return 1
+}
+
+// luaMatchLabels reports whether a set of labels (e.g. obj.metadata.labels,
+// possibly nil) matches a label selector. The selector is either a string
+// ("app=web,tier in (frontend,backend)") or a table with the fields of a
+// metav1.LabelSelector (matchLabels and matchExpressions), such as the
+// selector of a Deployment.
+func luaMatchLabels(l *lua.LState) int {
+	set := labels.Set{}
+	if tbl := l.OptTable(1, nil); tbl != nil {
+		tbl.ForEach(func(key, value lua.LValue) {
+			set[key.String()] = value.String()
+		})
+	}
+
+	var selector labels.Selector
+	var err error
+	switch s := l.CheckAny(2).(type) {
+	case lua.LString:
+		selector, err = labels.Parse(string(s))
+	case *lua.LTable:
+		selector, err = labelSelectorFromTable(s)
+	default:
+		l.ArgError(2, "label selector must be a string or a table")
+	}
+	if err != nil {
+		return luaFail(l, err)
+	}
+
+	l.Push(lua.LBool(selector.Matches(set)))
+	return 1
+}
+
+func labelSelectorFromTable(tbl *lua.LTable) (labels.Selector, error) {
+	data, err := json.Marshal(toGoValue(tbl))
+	if err != nil {
+		return nil, err
+	}
+	var labelSelector metav1.LabelSelector
+	if err := json.Unmarshal(data, &labelSelector); err != nil {
+		return nil, err
+	}
+	return metav1.LabelSelectorAsSelector(&labelSelector)
+}
+
+// luaParseQuantity converts a resource quantity ("500m", "1Gi") to a number:
+// "500m" is 0.5 and "1Gi" is 1073741824.
+func luaParseQuantity(l *lua.LState) int {
+	q, err := resource.ParseQuantity(l.CheckString(1))
+	if err != nil {
+		return luaFail(l, err)
+	}
+	l.Push(lua.LNumber(q.AsApproximateFloat64()))
+	return 1
+}
+
+// luaCompareQuantity returns -1, 0 or 1 depending on whether the first
+// resource quantity is lower than, equal to or greater than the second one.
+func luaCompareQuantity(l *lua.LState) int {
+	q1, err := resource.ParseQuantity(l.CheckString(1))
+	if err != nil {
+		return luaFail(l, err)
+	}
+	q2, err := resource.ParseQuantity(l.CheckString(2))
+	if err != nil {
+		return luaFail(l, err)
+	}
+	l.Push(lua.LNumber(q1.Cmp(q2)))
+	return 1
+}
+
+// luaContainers returns the init, regular and ephemeral containers of a Pod or
+// of the Pod template of a workload (Deployment, StatefulSet, DaemonSet,
+// ReplicaSet, Job, CronJob). Entries are the container tables of obj, so a
+// transform can modify them in place.
+func luaContainers(l *lua.LState) int {
+	obj := l.CheckTable(1)
+	result := l.NewTable()
+
+	podSpec := podSpecTable(obj)
+	if podSpec == nil {
+		l.Push(result)
+		return 1
+	}
+
+	for _, field := range []string{"initContainers", "containers", "ephemeralContainers"} {
+		containers, ok := podSpec.RawGetString(field).(*lua.LTable)
+		if !ok {
+			continue
+		}
+		containers.ForEach(func(_, container lua.LValue) {
+			result.Append(container)
+		})
+	}
+
+	l.Push(result)
+	return 1
+}
+
+// podSpecTable returns the Pod spec of a Pod or workload, nil if obj has none.
+func podSpecTable(obj *lua.LTable) *lua.LTable {
+	spec, ok := obj.RawGetString("spec").(*lua.LTable)
+	if !ok {
+		return nil
+	}
+
+	var template *lua.LTable
+	switch lua.LVAsString(obj.RawGetString("kind")) {
+	case "Pod":
+		return spec
+	case "CronJob":
+		jobTemplate, ok := spec.RawGetString("jobTemplate").(*lua.LTable)
+		if !ok {
+			return nil
+		}
+		jobSpec, ok := jobTemplate.RawGetString("spec").(*lua.LTable)
+		if !ok {
+			return nil
+		}
+		template, _ = jobSpec.RawGetString("template").(*lua.LTable)
+	default:
+		template, _ = spec.RawGetString("template").(*lua.LTable)
+	}
+	if template == nil {
+		return nil
+	}
+
+	podSpec, _ := template.RawGetString("spec").(*lua.LTable)
+	return podSpec
+}
+
+// toLuaValue converts a decoded JSON value to a Lua value.
+func toLuaValue(l *lua.LState, value interface{}) lua.LValue {
+	switch v := value.(type) {
+	case nil:
+		return lua.LNil
+	case bool:
+		return lua.LBool(v)
+	case float64:
+		return lua.LNumber(v)
+	case string:
+		return lua.LString(v)
+	case []interface{}:
+		tbl := l.CreateTable(len(v), 0)
+		for i := range v {
+			tbl.Append(toLuaValue(l, v[i]))
+		}
+		return tbl
+	case map[string]interface{}:
+		tbl := l.CreateTable(0, len(v))
+		for key, element := range v {
+			tbl.RawSetString(key, toLuaValue(l, element))
+		}
+		return tbl
+	default:
+		return lua.LString(fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"gianlucam76/k8s-cleaner/internal/controller/executor"
+)
+
+var _ = Describe("Lua helpers module", func() {
+	var resource *unstructured.Unstructured
+
+	BeforeEach(func() {
+		depl := &appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         randomString(),
+				Name:              randomString(),
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-48 * time.Hour)),
+				Labels:            map[string]string{"app": "web", "tier": "frontend"},
+				Annotations:       map[string]string{"cleaner/ttl": "1d"},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						InitContainers: []corev1.Container{{Name: "init", Image: "busybox:1.36.0"}},
+						Containers:     []corev1.Container{{Name: "app", Image: "nginx:1.25.3"}},
+					},
+				},
+			},
+		}
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(depl)
+		Expect(err).To(BeNil())
+		resource = &unstructured.Unstructured{Object: content}
+	})
+
+	DescribeTable("helpers evaluate as expected",
+		func(expression string) {
+			script := `
+local cleaner = require("cleaner")
+function evaluate(obj)
+  hs = {}
+  hs.matching = ` + expression + `
+  return hs
+end`
+			matching, _, err := executor.IsMatch(context.TODO(), resource, script, nil, nil, nil, nil, nil, nil, logr.Discard())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(matching).To(BeTrue())
+		},
+		Entry("parseTime", `cleaner.parseTime("2024-01-02T03:04:05Z") == 1704164645`),
+		Entry("parseTime invalid", `select(2, cleaner.parseTime("yesterday")) ~= nil`),
+		Entry("formatTime", `cleaner.formatTime(1704164645) == "2024-01-02T03:04:05Z"`),
+		Entry("age", `cleaner.age(obj.metadata.creationTimestamp) > cleaner.parseDuration(obj.metadata.annotations["cleaner/ttl"])`),
+		Entry("parseDuration", `cleaner.parseDuration("1h30m") == 5400 and cleaner.parseDuration("2w") == 1209600`),
+		Entry("now", `math.abs(cleaner.now() - os.time()) <= 1`),
+		Entry("semverCompare", `cleaner.semverCompare("1.25.3", "1.9.0") == 1`),
+		Entry("semverSatisfies", `cleaner.semverSatisfies("1.25.3", ">= 1.20, < 2.0")`),
+		Entry("regexMatch", `cleaner.regexMatch("^nginx:[0-9.]+$", obj.spec.template.spec.containers[1].image)`),
+		Entry("regexFind", `cleaner.regexFind("^(\\w+):(.*)$", "nginx:1.25.3")[3] == "1.25.3"`),
+		Entry("regexFind no match", `cleaner.regexFind("^redis", "nginx") == nil`),
+		Entry("regexReplace", `cleaner.regexReplace(":.*$", "nginx:1.25.3", ":latest") == "nginx:latest"`),
+		Entry("JSON round trip", `cleaner.fromJSON(cleaner.toJSON({a = {1, 2}})).a[2] == 2`),
+		Entry("YAML round trip", `cleaner.fromYAML(cleaner.toYAML(obj)).metadata.name == obj.metadata.name`),
+		Entry("matchLabels with string selector", `cleaner.matchLabels(obj.metadata.labels, "app=web,tier in (frontend,backend)")`),
+		Entry("matchLabels with LabelSelector", `cleaner.matchLabels(obj.metadata.labels,
+    {matchExpressions = {{key = "tier", operator = "NotIn", values = {"backend"}}}})`),
+		Entry("matchLabels with nil labels", `not cleaner.matchLabels(nil, "app")`),
+		Entry("parseQuantity", `cleaner.parseQuantity("500m") == 0.5 and cleaner.parseQuantity("1Ki") == 1024`),
+		Entry("compareQuantity", `cleaner.compareQuantity("1Gi", "1000Mi") == 1`),
+		Entry("containers", `#cleaner.containers(obj) == 2 and cleaner.containers(obj)[1].name == "init"`),
+	)
+})
@@ -0,0 +1,165 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	lua "github.com/yuin/gopher-lua"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// lookupCache memoizes the resources fetched by Evaluate scripts through
+// lookup and list during a single Cleaner run, so that evaluating N resources
+// referencing the same object results in a single API call.
+// Results are never invalidated: a new cache is created for every run.
+type lookupCache struct {
+	c client.Client
+
+	mu sync.Mutex
+	// objects maps a resource key to the resource, nil if it does not exist.
+	objects map[string]*unstructured.Unstructured
+	// lists maps a list key to the listed resources.
+	lists map[string][]unstructured.Unstructured
+}
+
+func newLookupCache(c client.Client) *lookupCache {
+	return &lookupCache{
+		c:       c,
+		objects: make(map[string]*unstructured.Unstructured),
+		lists:   make(map[string][]unstructured.Unstructured),
+	}
+}
+
+// get returns the resource with the given GroupVersionKind, namespace and
+// name, nil if it does not exist.
+func (lc *lookupCache) get(ctx context.Context, gvk schema.GroupVersionKind, namespace, name string,
+) (*unstructured.Unstructured, error) {
+
+	key := fmt.Sprintf("%s/%s/%s", gvk.String(), namespace, name)
+
+	lc.mu.Lock()
+	u, ok := lc.objects[key]
+	lc.mu.Unlock()
+	if ok {
+		return u, nil
+	}
+
+	u = &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	err := lc.c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, u)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		u = nil
+	}
+
+	lc.mu.Lock()
+	lc.objects[key] = u
+	lc.mu.Unlock()
+	return u, nil
+}
+
+// list returns the resources with the given GroupVersionKind in namespace (all
+// namespaces if empty) matching selector.
+func (lc *lookupCache) list(ctx context.Context, gvk schema.GroupVersionKind, namespace string,
+	selector labels.Selector) ([]unstructured.Unstructured, error) {
+
+	key := fmt.Sprintf("%s/%s/%s", gvk.String(), namespace, selector.String())
+
+	lc.mu.Lock()
+	items, ok := lc.lists[key]
+	lc.mu.Unlock()
+	if ok {
+		return items, nil
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := lc.c.List(ctx, list, client.InNamespace(namespace),
+		client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+
+	lc.mu.Lock()
+	lc.lists[key] = list.Items
+	lc.mu.Unlock()
+	return list.Items, nil
+}
+
+// setLookupFunctions defines the read-only lookup and list globals available
+// to Evaluate scripts:
+//
+//	lookup(group, version, kind, namespace, name)
+//	list({group=..., version=..., kind=...}, namespace, labelSelector)
+//
+// lookup returns the resource or nil if it does not exist; list returns an
+// array of resources. On any other failure both return nil and an error
+// message. Scripts receive a copy, so modifying it does not affect the cache.
+func setLookupFunctions(ctx context.Context, l *lua.LState, lc *lookupCache) {
+	l.SetGlobal("lookup", l.NewFunction(func(l *lua.LState) int {
+		gvk := schema.GroupVersionKind{
+			Group:   l.OptString(1, ""),
+			Version: l.CheckString(2),
+			Kind:    l.CheckString(3),
+		}
+		u, err := lc.get(ctx, gvk, l.OptString(4, ""), l.CheckString(5))
+		if err != nil {
+			return luaFail(l, err)
+		}
+		if u == nil {
+			l.Push(lua.LNil)
+			return 1
+		}
+		l.Push(mapToTable(u.UnstructuredContent()))
+		return 1
+	}))
+
+	l.SetGlobal("list", l.NewFunction(func(l *lua.LState) int {
+		gvkTable := l.CheckTable(1)
+		gvk := schema.GroupVersionKind{
+			Group:   lua.LVAsString(gvkTable.RawGetString("group")),
+			Version: lua.LVAsString(gvkTable.RawGetString("version")),
+			Kind:    lua.LVAsString(gvkTable.RawGetString("kind")),
+		}
+		if gvk.Version == "" || gvk.Kind == "" {
+			l.ArgError(1, "version and kind are required")
+		}
+		selector, err := labels.Parse(l.OptString(3, ""))
+		if err != nil {
+			return luaFail(l, err)
+		}
+		items, err := lc.list(ctx, gvk, l.OptString(2, ""), selector)
+		if err != nil {
+			return luaFail(l, err)
+		}
+		result := l.CreateTable(len(items), 0)
+		for i := range items {
+			result.Append(mapToTable(items[i].UnstructuredContent()))
+		}
+		l.Push(result)
+		return 1
+	}))
+}
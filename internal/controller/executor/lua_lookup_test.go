@@ -0,0 +1,141 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor_test
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"gianlucam76/k8s-cleaner/internal/controller/executor"
+)
+
+const (
+	// Matches ConfigMaps with no Secret of the same name.
+	evaluateLookup = `function evaluate(obj)
+  hs = {}
+  local secret, err = lookup("", "v1", "Secret", obj.metadata.namespace, obj.metadata.name)
+  hs.matching = err == nil and secret == nil
+  return hs
+end`
+
+	// Matches ConfigMaps sharing their namespace with exactly one Secret
+	// labeled app=web.
+	evaluateList = `function evaluate(obj)
+  hs = {}
+  local secrets = list({version = "v1", kind = "Secret"}, obj.metadata.namespace, "app=web")
+  hs.matching = #secrets == 1 and secrets[1].metadata.labels.app == "web"
+  return hs
+end`
+)
+
+var _ = Describe("Lua lookup and list", func() {
+	var ns *corev1.Namespace
+
+	BeforeEach(func() {
+		ns = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString()},
+		}
+		Expect(k8sClient.Create(context.TODO(), ns)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, ns)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), ns)).To(Succeed())
+	})
+
+	configMap := func(name string) *unstructured.Unstructured {
+		cm := &corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{APIVersion: apiVersionV1, Kind: kindConfigMap},
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name, Name: name},
+		}
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cm)
+		Expect(err).To(BeNil())
+		return &unstructured.Unstructured{Object: content}
+	}
+
+	createSecret := func(name string, labels map[string]string) *corev1.Secret {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name, Name: name, Labels: labels},
+		}
+		Expect(k8sClient.Create(context.TODO(), secret)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, secret)).To(Succeed())
+		return secret
+	}
+
+	It("lookup returns the resource, nil when it does not exist", func() {
+		name := randomString()
+		createSecret(name, nil)
+
+		matching, _, err := executor.IsMatch(context.TODO(), configMap(name), evaluateLookup, nil, nil,
+			nil, nil, nil, nil, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(matching).To(BeFalse())
+
+		matching, _, err = executor.IsMatch(context.TODO(), configMap(randomString()), evaluateLookup, nil, nil,
+			nil, nil, nil, nil, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(matching).To(BeTrue())
+	})
+
+	It("list returns the resources matching the label selector", func() {
+		createSecret(randomString(), map[string]string{"app": "web"})
+		createSecret(randomString(), map[string]string{"app": "db"})
+
+		matching, _, err := executor.IsMatch(context.TODO(), configMap(randomString()), evaluateList, nil, nil,
+			nil, nil, nil, nil, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(matching).To(BeTrue())
+	})
+
+	It("memoizes results for the lifetime of the cache", func() {
+		name := randomString()
+		secret := createSecret(name, map[string]string{"app": "web"})
+
+		lookups := executor.NewLookupCache(k8sClient)
+		for _, script := range []string{evaluateLookup, evaluateList} {
+			_, _, err := executor.IsMatch(context.TODO(), configMap(name), script, nil, lookups,
+				nil, nil, nil, nil, logr.Discard())
+			Expect(err).To(BeNil())
+		}
+
+		Expect(k8sClient.Delete(context.TODO(), secret)).To(Succeed())
+
+		By("reusing the cache, the deleted Secret is still seen")
+		matching, _, err := executor.IsMatch(context.TODO(), configMap(name), evaluateLookup, nil, lookups,
+			nil, nil, nil, nil, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(matching).To(BeFalse())
+		matching, _, err = executor.IsMatch(context.TODO(), configMap(name), evaluateList, nil, lookups,
+			nil, nil, nil, nil, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(matching).To(BeTrue())
+
+		By("with a new cache, the Secret is gone")
+		matching, _, err = executor.IsMatch(context.TODO(), configMap(name), evaluateLookup, nil,
+			executor.NewLookupCache(k8sClient), nil, nil, nil, nil, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(matching).To(BeTrue())
+	})
+})
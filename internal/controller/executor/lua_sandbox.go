@@ -0,0 +1,372 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+)
+
+// LuaLimit identifies which sandbox limit a Lua script exceeded.
+type LuaLimit string
+
+const (
+	// LuaLimitTimeout is exceeded when a script runs longer than its timeout.
+	LuaLimitTimeout = LuaLimit("Timeout")
+
+	// LuaLimitCallStack is exceeded when a script recurses too deeply.
+	LuaLimitCallStack = LuaLimit("CallStack")
+
+	// LuaLimitRegistry is exceeded when a script needs more registry slots
+	// than allowed.
+	LuaLimitRegistry = LuaLimit("Registry")
+)
+
+// LuaLimitError is returned when a Lua script is stopped because it exceeded
+// one of the sandbox limits.
+type LuaLimitError struct {
+	Limit LuaLimit
+	Err   error
+}
+
+func (e *LuaLimitError) Error() string {
+	return fmt.Sprintf("lua script exceeded %s limit: %v", e.Limit, e.Err)
+}
+
+func (e *LuaLimitError) Unwrap() error {
+	return e.Err
+}
+
+// Reason returns the Cleaner condition reason matching the exceeded limit.
+func (e *LuaLimitError) Reason() string {
+	switch e.Limit {
+	case LuaLimitTimeout:
+		return appsv1alpha1.ReasonLuaTimeout
+	case LuaLimitCallStack:
+		return appsv1alpha1.ReasonLuaCallStackOverflow
+	case LuaLimitRegistry:
+		return appsv1alpha1.ReasonLuaRegistryOverflow
+	}
+	return appsv1alpha1.ReasonFailed
+}
+
+// luaLimits are the resolved limits a single Lua state is created with.
+type luaLimits struct {
+	timeout         time.Duration
+	callStackSize   int
+	registryMaxSize int
+}
+
+const (
+	defaultLuaTimeout         = 30 * time.Second
+	defaultLuaRegistryMaxSize = 256 * 1024
+)
+
+var (
+	luaDefaultsMu sync.RWMutex
+	// luaDefaults are used for every LuaLimits field a Cleaner does not set.
+	luaDefaults = luaLimits{
+		timeout:         defaultLuaTimeout,
+		callStackSize:   lua.CallStackSize,
+		registryMaxSize: defaultLuaRegistryMaxSize,
+	}
+)
+
+// SetLuaDefaults sets the controller-wide Lua sandbox limits. A zero value
+// leaves the corresponding default unchanged.
+func SetLuaDefaults(timeout time.Duration, callStackSize, registryMaxSize int) {
+	luaDefaultsMu.Lock()
+	defer luaDefaultsMu.Unlock()
+
+	if timeout > 0 {
+		luaDefaults.timeout = timeout
+	}
+	if callStackSize > 0 {
+		luaDefaults.callStackSize = callStackSize
+	}
+	if registryMaxSize > 0 {
+		luaDefaults.registryMaxSize = registryMaxSize
+	}
+}
+
+// resolveLuaLimits merges the limits configured on a Cleaner (possibly nil)
+// with the controller-wide defaults.
+func resolveLuaLimits(limits *appsv1alpha1.LuaLimits) luaLimits {
+	luaDefaultsMu.RLock()
+	resolved := luaDefaults
+	luaDefaultsMu.RUnlock()
+
+	if limits == nil {
+		return resolved
+	}
+	if limits.Timeout != nil && limits.Timeout.Duration > 0 {
+		resolved.timeout = limits.Timeout.Duration
+	}
+	if limits.CallStackSize != nil {
+		resolved.callStackSize = int(*limits.CallStackSize)
+	}
+	if limits.RegistryMaxSize != nil {
+		resolved.registryMaxSize = int(*limits.RegistryMaxSize)
+	}
+	return resolved
+}
+
+// luaSandbox is a Lua state with only the safe subset of the standard library
+// loaded, running under a deadline and with bounded call stack and registry.
+type luaSandbox struct {
+	*lua.LState
+	ctx     context.Context
+	cancel  context.CancelFunc
+	timeout time.Duration
+	// tables are the tables reachable from the globals when the state was
+	// created, restored before a pooled sandbox is reused.
+	tables []luaTableSnapshot
+}
+
+// luaTableSnapshot is the content and metatable of a Lua table at a given time.
+type luaTableSnapshot struct {
+	table     *lua.LTable
+	fields    map[lua.LValue]lua.LValue
+	metatable lua.LValue
+}
+
+// snapshotLuaTables returns a snapshot of every table reachable from roots,
+// through fields or metatables.
+func snapshotLuaTables(roots ...lua.LValue) []luaTableSnapshot {
+	var snapshots []luaTableSnapshot
+	visited := make(map[*lua.LTable]bool)
+
+	var visit func(value lua.LValue)
+	visit = func(value lua.LValue) {
+		table, ok := value.(*lua.LTable)
+		if !ok || visited[table] {
+			return
+		}
+		visited[table] = true
+
+		snapshot := luaTableSnapshot{table: table, fields: make(map[lua.LValue]lua.LValue),
+			metatable: table.Metatable}
+		table.ForEach(func(key, value lua.LValue) {
+			snapshot.fields[key] = value
+		})
+		snapshots = append(snapshots, snapshot)
+
+		for key, value := range snapshot.fields {
+			visit(key)
+			visit(value)
+		}
+		visit(table.Metatable)
+	}
+
+	for i := range roots {
+		visit(roots[i])
+	}
+	return snapshots
+}
+
+// restore sets back the content and metatable of the table.
+func (s *luaTableSnapshot) restore() {
+	var added []lua.LValue
+	s.table.ForEach(func(key, _ lua.LValue) {
+		if _, ok := s.fields[key]; !ok {
+			added = append(added, key)
+		}
+	})
+	for i := range added {
+		s.table.RawSet(added[i], lua.LNil)
+	}
+	for key, value := range s.fields {
+		s.table.RawSet(key, value)
+	}
+	s.table.Metatable = s.metatable
+}
+
+// newLuaSandbox returns a sandboxed Lua state. The timeout starts now and
+// covers everything run on the state until Close is called.
+func newLuaSandbox(ctx context.Context, limits *appsv1alpha1.LuaLimits) *luaSandbox {
+	resolved := resolveLuaLimits(limits)
+
+	l := lua.NewState(lua.Options{
+		SkipOpenLibs:    true,
+		CallStackSize:   resolved.callStackSize,
+		RegistrySize:    min(lua.RegistrySize, resolved.registryMaxSize),
+		RegistryMaxSize: resolved.registryMaxSize,
+	})
+	openSandboxLibs(l)
+	l.PreloadModule(luaHelpersModuleName, loadLuaHelpers)
+
+	ctx, cancel := context.WithTimeout(ctx, resolved.timeout)
+	l.SetContext(ctx)
+
+	return &luaSandbox{LState: l, ctx: ctx, cancel: cancel, timeout: resolved.timeout}
+}
+
+// Close releases the Lua state and its deadline.
+func (s *luaSandbox) Close() {
+	s.LState.Close()
+	s.cancel()
+}
+
+// restart starts a new timeout for the sandbox.
+func (s *luaSandbox) restart(ctx context.Context) {
+	s.ctx, s.cancel = context.WithTimeout(ctx, s.timeout)
+	s.SetContext(s.ctx)
+}
+
+// resetGlobals restores the globals, and every table reachable from them such
+// as the string library or package.loaded, as they were when the sandbox was
+// created, so that nothing a script defines or modifies leaks into the next
+// one run on the sandbox. Modules loaded by a script are loaded again by the
+// next one requiring them.
+func (s *luaSandbox) resetGlobals() {
+	for i := range s.tables {
+		s.tables[i].restore()
+	}
+	s.SetTop(0)
+}
+
+// luaSandboxPool keeps sandboxes for reuse by the evaluations of a
+// ResourceSelector, saving the cost of creating a Lua state and loading its
+// libraries for every resource. It is safe for concurrent use.
+type luaSandboxPool struct {
+	limits *appsv1alpha1.LuaLimits
+
+	mu   sync.Mutex
+	idle []*luaSandbox
+}
+
+func newLuaSandboxPool(limits *appsv1alpha1.LuaLimits) *luaSandboxPool {
+	return &luaSandboxPool{limits: limits}
+}
+
+// get returns an idle sandbox, or a new one. Its timeout starts now.
+func (p *luaSandboxPool) get(ctx context.Context) *luaSandbox {
+	p.mu.Lock()
+	if n := len(p.idle); n > 0 {
+		s := p.idle[n-1]
+		p.idle = p.idle[:n-1]
+		p.mu.Unlock()
+		s.restart(ctx)
+		return s
+	}
+	p.mu.Unlock()
+
+	s := newLuaSandbox(ctx, p.limits)
+	s.tables = snapshotLuaTables(s.G.Global, s.GetMetatable(lua.LString("")))
+	return s
+}
+
+// put returns s to the pool. A sandbox a script failed on is closed instead:
+// it may have been stopped halfway, leaving its state inconsistent.
+func (p *luaSandboxPool) put(s *luaSandbox, failed bool) {
+	s.cancel()
+	if failed {
+		s.LState.Close()
+		return
+	}
+
+	s.resetGlobals()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.idle = append(p.idle, s)
+}
+
+// close releases all idle sandboxes.
+func (p *luaSandboxPool) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := range p.idle {
+		p.idle[i].LState.Close()
+	}
+	p.idle = nil
+}
+
+// wrapError returns a *LuaLimitError if err was caused by the script exceeding
+// one of the sandbox limits, err otherwise.
+func (s *luaSandbox) wrapError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch {
+	case errors.Is(s.ctx.Err(), context.DeadlineExceeded):
+		return &LuaLimitError{Limit: LuaLimitTimeout, Err: err}
+	case strings.Contains(err.Error(), "registry overflow"):
+		return &LuaLimitError{Limit: LuaLimitRegistry, Err: err}
+	case strings.Contains(err.Error(), "stack overflow"):
+		return &LuaLimitError{Limit: LuaLimitCallStack, Err: err}
+	}
+	return err
+}
+
+// openSandboxLibs loads the standard libraries scripts may use. io, debug and
+// channel are not loaded; os is reduced to its time functions, which
+// existing scripts rely on to compute ages; functions loading code from the
+// filesystem are removed.
+func openSandboxLibs(l *lua.LState) {
+	for _, lib := range []struct {
+		name string
+		fn   lua.LGFunction
+	}{
+		{lua.LoadLibName, lua.OpenPackage},
+		{lua.BaseLibName, lua.OpenBase},
+		{lua.TabLibName, lua.OpenTable},
+		{lua.StringLibName, lua.OpenString},
+		{lua.MathLibName, lua.OpenMath},
+		{lua.CoroutineLibName, lua.OpenCoroutine},
+		{lua.OsLibName, lua.OpenOs},
+	} {
+		l.Push(l.NewFunction(lib.fn))
+		l.Push(lua.LString(lib.name))
+		l.Call(1, 0)
+	}
+
+	osTable := l.NewTable()
+	if fullOs, ok := l.GetGlobal(lua.OsLibName).(*lua.LTable); ok {
+		for _, name := range []string{"time", "date", "difftime", "clock"} {
+			osTable.RawSetString(name, fullOs.RawGetString(name))
+		}
+	}
+	l.SetGlobal(lua.OsLibName, osTable)
+
+	l.SetGlobal("dofile", lua.LNil)
+	l.SetGlobal("loadfile", lua.LNil)
+
+	if pkg, ok := l.GetGlobal(lua.LoadLibName).(*lua.LTable); ok {
+		pkg.RawSetString("path", lua.LString(""))
+		pkg.RawSetString("cpath", lua.LString(""))
+		// Only modules registered in package.preload can be required. require
+		// keeps its own reference to the loaders table, so it is trimmed in
+		// place.
+		if loaders, ok := pkg.RawGetString("loaders").(*lua.LTable); ok {
+			for i := loaders.Len(); i > 1; i-- {
+				loaders.RawSetInt(i, lua.LNil)
+			}
+		}
+		if loaded, ok := pkg.RawGetString("loaded").(*lua.LTable); ok {
+			loaded.RawSetString(lua.OsLibName, osTable)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+	"gianlucam76/k8s-cleaner/internal/controller/executor"
+)
+
+var _ = Describe("Lua sandbox", func() {
+	var resource *unstructured.Unstructured
+
+	BeforeEach(func() {
+		resource = podResource(randomString(), randomString())
+	})
+
+	DescribeTable("stops scripts exceeding a limit",
+		func(script string, limits *appsv1alpha1.LuaLimits, expected executor.LuaLimit, reason string) {
+			_, _, err := executor.IsMatch(context.TODO(), resource, script, limits, nil, nil, nil, nil, nil, logr.Discard())
+			Expect(err).To(HaveOccurred())
+
+			var limitErr *executor.LuaLimitError
+			Expect(errors.As(err, &limitErr)).To(BeTrue())
+			Expect(limitErr.Limit).To(Equal(expected))
+			Expect(limitErr.Reason()).To(Equal(reason))
+		},
+		Entry("infinite loop", `
+function evaluate(obj)
+  while true do end
+end`,
+			&appsv1alpha1.LuaLimits{Timeout: &metav1.Duration{Duration: 100 * time.Millisecond}},
+			executor.LuaLimitTimeout, appsv1alpha1.ReasonLuaTimeout),
+		Entry("unbounded recursion", `
+function f(n)
+  return f(n + 1) + 1
+end
+function evaluate(obj)
+  return f(1)
+end`,
+			&appsv1alpha1.LuaLimits{CallStackSize: ptr.To(int32(64))},
+			executor.LuaLimitCallStack, appsv1alpha1.ReasonLuaCallStackOverflow),
+		Entry("too many values on the registry", `
+function evaluate(obj)
+  local t = {}
+  for i = 1, 100000 do t[i] = i end
+  return unpack(t)
+end`,
+			&appsv1alpha1.LuaLimits{RegistryMaxSize: ptr.To(int32(4096))},
+			executor.LuaLimitRegistry, appsv1alpha1.ReasonLuaRegistryOverflow),
+	)
+
+	It("only exposes the safe subset of the standard library", func() {
+		script := `
+function evaluate(obj)
+  hs = {}
+  hs.matching = io == nil and debug == nil and dofile == nil and loadfile == nil and
+                os.execute == nil and os.getenv == nil and os.remove == nil and
+                os.time ~= nil and os.date ~= nil and os.difftime ~= nil and
+                require("os").exit == nil
+  return hs
+end`
+
+		matching, _, err := executor.IsMatch(context.TODO(), resource, script, nil, nil, nil, nil, nil, nil, logr.Discard())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(matching).To(BeTrue())
+	})
+
+	It("does not load modules from the filesystem", func() {
+		script := `
+local helpers = require("helpers")
+function evaluate(obj)
+  return {}
+end`
+
+		_, _, err := executor.IsMatch(context.TODO(), resource, script, nil, nil, nil, nil, nil, nil, logr.Discard())
+		Expect(err).To(HaveOccurred())
+
+		var limitErr *executor.LuaLimitError
+		Expect(errors.As(err, &limitErr)).To(BeFalse())
+	})
+
+	It("does not leak globals between evaluations sharing a pooled Lua state", func() {
+		pool := executor.NewLuaSandboxPool(nil)
+
+		script := `
+function evaluate()
+  hs = {}
+  hs.matching = seen == nil
+  seen = true
+  string.custom = "set"
+  return hs
+end`
+		for range 3 {
+			matching, err := executor.IsMatchWithPool(context.TODO(), resource, script, pool)
+			Expect(err).To(BeNil())
+			Expect(matching).To(BeTrue())
+		}
+
+		// A script failing does not affect the next ones.
+		_, err := executor.IsMatchWithPool(context.TODO(), resource, `
+function evaluate()
+  error("failed")
+end`, pool)
+		Expect(err).ToNot(BeNil())
+
+		matching, err := executor.IsMatchWithPool(context.TODO(), resource, script, pool)
+		Expect(err).To(BeNil())
+		Expect(matching).To(BeTrue())
+	})
+
+	It("does not leak changes to shared tables between evaluations sharing a pooled Lua state", func() {
+		pool := executor.NewLuaSandboxPool(nil)
+
+		mutate := `
+function evaluate()
+  local cleaner = require("cleaner")
+  string.upper = function(s) return "mutated" end
+  string.custom = "set"
+  table.insert = nil
+  cleaner.custom = "set"
+  getmetatable("").__index = {}
+  hs = {}
+  hs.matching = true
+  return hs
+end`
+		matching, err := executor.IsMatchWithPool(context.TODO(), resource, mutate, pool)
+		Expect(err).To(BeNil())
+		Expect(matching).To(BeTrue())
+
+		check := `
+function evaluate()
+  local cleaner = require("cleaner")
+  hs = {}
+  hs.matching = string.upper("a") == "A" and string.custom == nil and table.insert ~= nil and
+    cleaner.custom == nil and ("a"):upper() == "A"
+  return hs
+end`
+		matching, err = executor.IsMatchWithPool(context.TODO(), resource, check, pool)
+		Expect(err).To(BeNil())
+		Expect(matching).To(BeTrue())
+	})
+})
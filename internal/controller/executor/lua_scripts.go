@@ -0,0 +1,263 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/ast"
+	"github.com/yuin/gopher-lua/parse"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+)
+
+// luaChunkName is the name compiled scripts are given, the one DoString uses,
+// so that error messages point to "<string>:line".
+const luaChunkName = "<string>"
+
+// luaScriptCache keeps the compiled scripts of every Cleaner, so that a script
+// is parsed once per Cleaner generation instead of once per resource. Compiled
+// scripts are immutable and shared by all Lua states running them.
+type luaScriptCache struct {
+	mu sync.RWMutex
+	// protos holds compiled scripts by source.
+	protos map[string]*lua.FunctionProto
+	// cleaners holds, by Cleaner name, the generation its scripts were
+	// compiled for and their sources.
+	cleaners map[string]cleanerScripts
+}
+
+type cleanerScripts struct {
+	generation int64
+	sources    []string
+}
+
+var luaScripts = &luaScriptCache{
+	protos:   make(map[string]*lua.FunctionProto),
+	cleaners: make(map[string]cleanerScripts),
+}
+
+// load compiles the scripts of cleaner, unless they were already compiled for
+// its current generation. Scripts no Cleaner uses anymore are dropped.
+func (c *luaScriptCache) load(cleaner *appsv1alpha1.Cleaner) error {
+	c.mu.RLock()
+	cached, ok := c.cleaners[cleaner.Name]
+	c.mu.RUnlock()
+	if ok && cached.generation == cleaner.Generation {
+		return nil
+	}
+
+	protos, err := compileCleanerScripts(cleaner)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	sources := make([]string, 0, len(protos))
+	for source, proto := range protos {
+		sources = append(sources, source)
+		c.protos[source] = proto
+	}
+	c.cleaners[cleaner.Name] = cleanerScripts{generation: cleaner.Generation, sources: sources}
+	c.prune()
+	return nil
+}
+
+// forget drops the scripts of Cleaner cleanerName.
+func (c *luaScriptCache) forget(cleanerName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.cleaners, cleanerName)
+	c.prune()
+}
+
+// prune drops the compiled scripts no Cleaner uses. Must be called with the
+// lock held.
+func (c *luaScriptCache) prune() {
+	used := make(map[string]bool, len(c.protos))
+	for _, scripts := range c.cleaners {
+		for i := range scripts.sources {
+			used[scripts.sources[i]] = true
+		}
+	}
+	for source := range c.protos {
+		if !used[source] {
+			delete(c.protos, source)
+		}
+	}
+}
+
+// get returns script compiled. Scripts not in the cache, for instance the ones
+// of a Cleaner being validated, are compiled but not cached.
+func (c *luaScriptCache) get(script string) (*lua.FunctionProto, error) {
+	c.mu.RLock()
+	proto, ok := c.protos[script]
+	c.mu.RUnlock()
+	if ok {
+		return proto, nil
+	}
+	return compileLua(script)
+}
+
+// LoadLuaScripts compiles the Lua scripts of cleaner, unless they were already
+// compiled for its current generation. It returns an error naming the first
+// script that does not compile.
+func LoadLuaScripts(cleaner *appsv1alpha1.Cleaner) error {
+	return luaScripts.load(cleaner)
+}
+
+// ForgetLuaScripts drops the compiled scripts of Cleaner cleanerName. Called
+// when the Cleaner is deleted.
+func ForgetLuaScripts(cleanerName string) {
+	luaScripts.forget(cleanerName)
+}
+
+// cleanerLuaScript is a Lua script of a Cleaner.
+type cleanerLuaScript struct {
+	path   *field.Path
+	source string
+	// function is the global function the script must define.
+	function string
+}
+
+// cleanerLuaScripts returns the Lua scripts of cleaner, skipping unset ones.
+func cleanerLuaScripts(cleaner *appsv1alpha1.Cleaner) []cleanerLuaScript {
+	spec := field.NewPath("spec")
+	policySet := spec.Child("resourcePolicySet")
+
+	scripts := make([]cleanerLuaScript, 0)
+	for i := range cleaner.Spec.ResourcePolicySet.ResourceSelectors {
+		scripts = append(scripts, cleanerLuaScript{
+			path:     policySet.Child("resourceSelectors").Index(i).Child("evaluate"),
+			source:   cleaner.Spec.ResourcePolicySet.ResourceSelectors[i].Evaluate,
+			function: "evaluate",
+		})
+	}
+	scripts = append(scripts,
+		cleanerLuaScript{path: policySet.Child("aggregatedSelection"),
+			source: cleaner.Spec.ResourcePolicySet.AggregatedSelection, function: "evaluate"},
+		cleanerLuaScript{path: spec.Child("transform"), source: cleaner.Spec.Transform, function: "transform"})
+	for i := range cleaner.Spec.Actions {
+		step := spec.Child("actions").Index(i)
+		scripts = append(scripts,
+			cleanerLuaScript{path: step.Child("evaluate"), source: cleaner.Spec.Actions[i].Evaluate,
+				function: "evaluate"},
+			cleanerLuaScript{path: step.Child("transform"), source: cleaner.Spec.Actions[i].Transform,
+				function: "transform"})
+	}
+
+	return slices.DeleteFunc(scripts, func(s cleanerLuaScript) bool { return s.source == "" })
+}
+
+// compileCleanerScripts compiles every Lua script of cleaner, returning them
+// by source. The error names the script that failed to compile.
+func compileCleanerScripts(cleaner *appsv1alpha1.Cleaner) (map[string]*lua.FunctionProto, error) {
+	scripts := cleanerLuaScripts(cleaner)
+	protos := make(map[string]*lua.FunctionProto, len(scripts))
+	for i := range scripts {
+		if _, ok := protos[scripts[i].source]; ok {
+			continue
+		}
+		proto, err := compileLua(scripts[i].source)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", scripts[i].path, err)
+		}
+		protos[scripts[i].source] = proto
+	}
+	return protos, nil
+}
+
+// luaValidationTimeout bounds how long the top level of a script may run when
+// a Cleaner is validated, well within the admission webhook timeout.
+const luaValidationTimeout = time.Second
+
+// ValidateLuaScripts verifies every Lua script of cleaner compiles and
+// defines the function the Cleaner calls.
+func ValidateLuaScripts(cleaner *appsv1alpha1.Cleaner) field.ErrorList {
+	var errs field.ErrorList
+	for _, script := range cleanerLuaScripts(cleaner) {
+		proto, err := compileLua(script.source)
+		if err != nil {
+			errs = append(errs, field.Invalid(script.path, "<script>", err.Error()))
+			continue
+		}
+		if !definesFunction(proto, script.function, cleaner.Spec.LuaLimits) {
+			errs = append(errs, field.Invalid(script.path, "<script>",
+				fmt.Sprintf("script does not define function %s", script.function)))
+		}
+	}
+	return errs
+}
+
+// definesFunction runs the top level of proto in a fresh sandbox and returns
+// true if it leaves a global function name, however the script defines it.
+// The sandbox has none of the globals and lookup functions set when the
+// Cleaner runs, so a top level failing without them cannot be checked here:
+// it is accepted, and its error reported when the Cleaner runs.
+func definesFunction(proto *lua.FunctionProto, name string, limits *appsv1alpha1.LuaLimits) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), luaValidationTimeout)
+	defer cancel()
+
+	l := newLuaSandbox(ctx, limits)
+	defer l.Close()
+
+	l.Push(l.NewFunctionFromProto(proto))
+	if err := l.PCall(0, lua.MultRet, nil); err != nil {
+		return true
+	}
+	_, ok := l.GetGlobal(name).(*lua.LFunction)
+	return ok
+}
+
+// parseLua parses script.
+func parseLua(script string) ([]ast.Stmt, error) {
+	chunk, err := parse.Parse(strings.NewReader(script), luaChunkName)
+	if err != nil {
+		// Parse errors end with a newline.
+		return nil, errors.New(strings.TrimSpace(err.Error()))
+	}
+	return chunk, nil
+}
+
+// compileLua parses and compiles script.
+func compileLua(script string) (*lua.FunctionProto, error) {
+	chunk, err := parseLua(script)
+	if err != nil {
+		return nil, err
+	}
+	return lua.Compile(chunk, luaChunkName)
+}
+
+// loadScript runs script on l, so that the functions it defines can be
+// called. The script is taken compiled from the cache.
+func loadScript(l *lua.LState, script string) error {
+	proto, err := luaScripts.get(script)
+	if err != nil {
+		return err
+	}
+	l.Push(l.NewFunctionFromProto(proto))
+	return l.PCall(0, lua.MultRet, nil)
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+	"gianlucam76/k8s-cleaner/internal/controller/executor"
+)
+
+var _ = Describe("Lua scripts", func() {
+	evaluate := `
+function evaluate()
+  hs = {}
+  hs.matching = true
+  return hs
+end`
+
+	newScriptCleaner := func(script string) *appsv1alpha1.Cleaner {
+		return &appsv1alpha1.Cleaner{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString(), Generation: 1},
+			Spec: appsv1alpha1.CleanerSpec{
+				ResourcePolicySet: appsv1alpha1.ResourcePolicySet{
+					ResourceSelectors: []appsv1alpha1.ResourceSelector{
+						{Kind: "Pod", Group: "", Version: "v1", Evaluate: script},
+					},
+				},
+				Action: appsv1alpha1.ActionScan,
+			},
+		}
+	}
+
+	It("compiles scripts once per Cleaner generation", func() {
+		cleaner := newScriptCleaner(evaluate)
+		Expect(executor.LoadLuaScripts(cleaner)).To(Succeed())
+
+		generation, sources, ok := executor.CompiledLuaScripts(cleaner.Name)
+		Expect(ok).To(BeTrue())
+		Expect(generation).To(Equal(int64(1)))
+		Expect(sources).To(ConsistOf(evaluate))
+
+		// Same generation: the cached scripts are kept even if the spec changed.
+		updated := evaluate + "\n-- updated"
+		cleaner.Spec.ResourcePolicySet.ResourceSelectors[0].Evaluate = updated
+		Expect(executor.LoadLuaScripts(cleaner)).To(Succeed())
+		_, sources, _ = executor.CompiledLuaScripts(cleaner.Name)
+		Expect(sources).To(ConsistOf(evaluate))
+
+		cleaner.Generation = 2
+		Expect(executor.LoadLuaScripts(cleaner)).To(Succeed())
+		generation, sources, _ = executor.CompiledLuaScripts(cleaner.Name)
+		Expect(generation).To(Equal(int64(2)))
+		Expect(sources).To(ConsistOf(updated))
+
+		executor.ForgetLuaScripts(cleaner.Name)
+		_, _, ok = executor.CompiledLuaScripts(cleaner.Name)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("reports the script that does not compile", func() {
+		cleaner := newScriptCleaner(evaluate)
+		cleaner.Spec.Actions = []appsv1alpha1.ActionStep{
+			{Name: "scan", Action: appsv1alpha1.ActionScan, Evaluate: "function evaluate(obj"},
+		}
+
+		err := executor.LoadLuaScripts(cleaner)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(HavePrefix("spec.actions[0].evaluate: "))
+
+		_, _, ok := executor.CompiledLuaScripts(cleaner.Name)
+		Expect(ok).To(BeFalse())
+	})
+})
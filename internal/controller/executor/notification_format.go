@@ -62,9 +62,34 @@ func resourceRef(ref *corev1.ObjectReference) string {
 	return ref.Name
 }
 
+// resourceLines renders resources, the Action skipped or failed on, under
+// header. render renders one resource and moreFormat the count of resources
+// left out. It returns nil if resources is empty.
+func resourceLines(resources []appsv1alpha1.ResourceInfo, header, moreFormat string,
+	render func(info *appsv1alpha1.ResourceInfo) string) []string {
+
+	if len(resources) == 0 {
+		return nil
+	}
+
+	shown, omitted := truncateResourceInfo(resources)
+	lines := make([]string, 0, len(shown)+2)
+	lines = append(lines, header)
+	for i := range shown {
+		lines = append(lines, render(&shown[i]))
+	}
+	if omitted > 0 {
+		lines = append(lines, fmt.Sprintf(moreFormat, omitted))
+	}
+	return lines
+}
+
 // reportSummary is the one-line "what happened" summary shown at the top of
 // a formatted notification.
 func reportSummary(reportSpec *appsv1alpha1.ReportSpec) string {
+	if reportSpec.DryRun {
+		return fmt.Sprintf("%s (dry run) — %d resource(s)", reportSpec.Action, len(reportSpec.ResourceInfo))
+	}
 	return fmt.Sprintf("%s — %d resource(s)", reportSpec.Action, len(reportSpec.ResourceInfo))
 }
 
@@ -72,9 +97,9 @@ func reportSummary(reportSpec *appsv1alpha1.ReportSpec) string {
 
 func slackColorForAction(action appsv1alpha1.Action) string {
 	switch action {
-	case appsv1alpha1.ActionDelete:
+	case appsv1alpha1.ActionDelete, appsv1alpha1.ActionEvict, appsv1alpha1.ActionDrain:
 		return "#e01e5a"
-	case appsv1alpha1.ActionTransform:
+	case appsv1alpha1.ActionTransform, appsv1alpha1.ActionScale, appsv1alpha1.ActionLabel:
 		return "#ecb22e"
 	case appsv1alpha1.ActionScan:
 		return "#2eb67d"
@@ -88,9 +113,9 @@ func slackColorForAction(action appsv1alpha1.Action) string {
 // support for coloring arbitrary message text.
 func slackDotForAction(action appsv1alpha1.Action) string {
 	switch action {
-	case appsv1alpha1.ActionDelete:
+	case appsv1alpha1.ActionDelete, appsv1alpha1.ActionEvict, appsv1alpha1.ActionDrain:
 		return "🔴"
-	case appsv1alpha1.ActionTransform:
+	case appsv1alpha1.ActionTransform, appsv1alpha1.ActionScale, appsv1alpha1.ActionLabel:
 		return "🟡"
 	case appsv1alpha1.ActionScan:
 		return "🟢"
@@ -120,6 +145,11 @@ func buildSlackAttachment(reportSpec *appsv1alpha1.ReportSpec, cleanerName strin
 	if len(lines) == 0 {
 		lines = append(lines, "_No resources matched._")
 	}
+	render := func(info *appsv1alpha1.ResourceInfo) string {
+		return fmt.Sprintf("• *%s* `%s` — %s", info.Resource.Kind, resourceRef(&info.Resource), info.Message)
+	}
+	lines = append(lines, resourceLines(reportSpec.SkippedResources, "*Skipped*", "_...and %d more_", render)...)
+	lines = append(lines, resourceLines(reportSpec.FailedResources, "*Failed*", "_...and %d more_", render)...)
 
 	return slack.Attachment{
 		Color:      slackColorForAction(reportSpec.Action),
@@ -135,9 +165,9 @@ func buildSlackAttachment(reportSpec *appsv1alpha1.ReportSpec, cleanerName strin
 
 func teamsContainerStyleForAction(action appsv1alpha1.Action) string {
 	switch action {
-	case appsv1alpha1.ActionDelete:
+	case appsv1alpha1.ActionDelete, appsv1alpha1.ActionEvict, appsv1alpha1.ActionDrain:
 		return adaptivecard.ContainerStyleAttention
-	case appsv1alpha1.ActionTransform:
+	case appsv1alpha1.ActionTransform, appsv1alpha1.ActionScale, appsv1alpha1.ActionLabel:
 		return adaptivecard.ContainerStyleWarning
 	case appsv1alpha1.ActionScan:
 		return adaptivecard.ContainerStyleGood
@@ -177,6 +207,14 @@ func buildTeamsCard(reportSpec *appsv1alpha1.ReportSpec, message string) (adapti
 	if len(shown) == 0 {
 		container.Items = append(container.Items, adaptivecard.NewTextBlock("No resources matched.", true))
 	}
+	render := func(info *appsv1alpha1.ResourceInfo) string {
+		return fmt.Sprintf("**%s** %s — %s", info.Resource.Kind, resourceRef(&info.Resource), info.Message)
+	}
+	others := resourceLines(reportSpec.SkippedResources, "**Skipped**", "...and %d more", render)
+	others = append(others, resourceLines(reportSpec.FailedResources, "**Failed**", "...and %d more", render)...)
+	for i := range others {
+		container.Items = append(container.Items, adaptivecard.NewTextBlock(others[i], true))
+	}
 
 	if err := card.AddContainer(false, container); err != nil {
 		return card, err
@@ -189,9 +227,9 @@ func buildTeamsCard(reportSpec *appsv1alpha1.ReportSpec, message string) (adapti
 
 func discordColorForAction(action appsv1alpha1.Action) int {
 	switch action {
-	case appsv1alpha1.ActionDelete:
+	case appsv1alpha1.ActionDelete, appsv1alpha1.ActionEvict, appsv1alpha1.ActionDrain:
 		return discordColorDelete
-	case appsv1alpha1.ActionTransform:
+	case appsv1alpha1.ActionTransform, appsv1alpha1.ActionScale, appsv1alpha1.ActionLabel:
 		return discordColorTransform
 	case appsv1alpha1.ActionScan:
 		return discordColorScan
@@ -220,6 +258,11 @@ func buildDiscordEmbed(reportSpec *appsv1alpha1.ReportSpec) *discordgo.MessageEm
 	if len(lines) == 0 {
 		lines = append(lines, "No resources matched.")
 	}
+	render := func(info *appsv1alpha1.ResourceInfo) string {
+		return fmt.Sprintf("**%s** %s — %s", info.Resource.Kind, resourceRef(&info.Resource), info.Message)
+	}
+	lines = append(lines, resourceLines(reportSpec.SkippedResources, "**Skipped**", "...and %d more", render)...)
+	lines = append(lines, resourceLines(reportSpec.FailedResources, "**Failed**", "...and %d more", render)...)
 
 	return &discordgo.MessageEmbed{
 		Title:       reportSummary(reportSpec),
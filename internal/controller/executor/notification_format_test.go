@@ -156,6 +156,35 @@ var _ = Describe("Notification formatting", func() {
 		Expect(embed.Description).To(ContainSubstring("unused"))
 		Expect(embed.Description).ToNot(ContainSubstring("{\""))
 	})
+
+	It("formatted notifications list skipped and failed resources with their reason", func() {
+		skipped := newResourceInfo("Node", "worker-1", "cordoned, 1 pod(s) remaining (1 blocked)")
+		skipped.Resource.Namespace = ""
+		failed := newResourceInfo("Node", "worker-2", "failed to evict pod")
+		failed.Resource.Namespace = ""
+		reportSpec := &appsv1alpha1.ReportSpec{
+			Action:           appsv1alpha1.ActionDrain,
+			SkippedCount:     1,
+			SkippedResources: []appsv1alpha1.ResourceInfo{skipped},
+			FailedCount:      1,
+			FailedResources:  []appsv1alpha1.ResourceInfo{failed},
+		}
+
+		attachment := executor.BuildSlackAttachment(reportSpec, "drain-idle-nodes")
+		Expect(attachment.Text).To(ContainSubstring("Skipped"))
+		Expect(attachment.Text).To(ContainSubstring("worker-1"))
+		Expect(attachment.Text).To(ContainSubstring("1 blocked"))
+		Expect(attachment.Text).To(ContainSubstring("Failed"))
+		Expect(attachment.Text).To(ContainSubstring("worker-2"))
+
+		card, err := executor.BuildTeamsCard(reportSpec, "This report has been generated by k8s-cleaner")
+		Expect(err).To(BeNil())
+		Expect(cardText(&card)).To(ContainSubstring("worker-1"))
+
+		embed := executor.BuildDiscordEmbed(reportSpec)
+		Expect(embed.Description).To(ContainSubstring("worker-1"))
+		Expect(embed.Description).To(ContainSubstring("worker-2"))
+	})
 })
 
 // cardText concatenates every TextBlock's text in a Teams Adaptive Card, for
@@ -67,15 +67,24 @@ type telegramInfo struct {
 }
 
 // sendNotification delivers notification
-func sendNotifications(ctx context.Context, resources []ResourceResult,
-	cleaner *appsv1alpha1.Cleaner, logger logr.Logger) error {
+func sendNotifications(ctx context.Context, resources, skippedResources, failedResources []ResourceResult,
+	steps []appsv1alpha1.StepReport, cleaner *appsv1alpha1.Cleaner, logger logr.Logger) error {
 
 	reportSpec := &appsv1alpha1.ReportSpec{}
 	if len(cleaner.Spec.Notifications) > 0 {
 		reportSpec = generateReportSpec(resources, cleaner)
+		reportSpec.SkippedCount = len(skippedResources)
+		reportSpec.SkippedResources = resourceInfos(skippedResources)
+		reportSpec.FailedCount = len(failedResources)
+		reportSpec.FailedResources = resourceInfos(failedResources)
+		reportSpec.Steps = steps
 	}
+	hasResources := len(resources) != 0 || len(skippedResources) != 0 || len(failedResources) != 0
 
 	message := fmt.Sprintf("This report has been generated by k8s-cleaner for instance: %s", cleaner.Name)
+	if isDryRun(cleaner) {
+		message += " (dry run: no resource was actually modified)"
+	}
 
 	if len(resources) == 0 {
 		logger.V(logs.LogDebug).Info("no resources found. Only Report instance will be updated.")
@@ -92,27 +101,27 @@ func sendNotifications(ctx context.Context, resources []ResourceResult,
 		case appsv1alpha1.NotificationTypeCleanerReport:
 			err = createReportInstance(ctx, cleaner, addRollbackResourceData(reportSpec, resources, cleaner, logger), logger)
 		case appsv1alpha1.NotificationTypeSlack:
-			if len(resources) != 0 {
+			if hasResources {
 				err = sendSlackNotification(ctx, reportSpec, message, cleaner.Name, notification, logger)
 			}
 		case appsv1alpha1.NotificationTypeWebex:
-			if len(resources) != 0 {
+			if hasResources {
 				err = sendWebexNotification(ctx, reportSpec, message, notification, logger)
 			}
 		case appsv1alpha1.NotificationTypeDiscord:
-			if len(resources) != 0 {
+			if hasResources {
 				err = sendDiscordNotification(ctx, reportSpec, message, notification, logger)
 			}
 		case appsv1alpha1.NotificationTypeTeams:
-			if len(resources) != 0 {
+			if hasResources {
 				err = sendTeamsNotification(ctx, reportSpec, message, notification, logger)
 			}
 		case appsv1alpha1.NotificationTypeTelegram:
-			if len(resources) != 0 {
+			if hasResources {
 				err = sendTelegramNotification(ctx, reportSpec, message, notification, logger)
 			}
 		case appsv1alpha1.NotificationTypeSMTP:
-			if len(resources) != 0 {
+			if hasResources {
 				err = sendSmtpNotification(ctx, reportSpec, message, notification, logger)
 			}
 		case appsv1alpha1.NotificationTypeEvent:
@@ -136,33 +145,45 @@ func sendNotifications(ctx context.Context, resources []ResourceResult,
 
 func generateReportSpec(resources []ResourceResult, cleaner *appsv1alpha1.Cleaner) *appsv1alpha1.ReportSpec {
 	reportSpec := appsv1alpha1.ReportSpec{}
-	reportSpec.Action = cleaner.Spec.Action
+	reportSpec.Action = cleanerAction(cleaner)
+	reportSpec.DryRun = isDryRun(cleaner)
+
+	reportSpec.ResourceInfo = resourceInfos(resources)
 
-	reportSpec.ResourceInfo = make([]appsv1alpha1.ResourceInfo, len(resources))
+	return &reportSpec
+}
+
+func resourceInfos(resources []ResourceResult) []appsv1alpha1.ResourceInfo {
+	infos := make([]appsv1alpha1.ResourceInfo, len(resources))
 	for i := range resources {
-		reportSpec.ResourceInfo[i] = appsv1alpha1.ResourceInfo{
+		infos[i] = appsv1alpha1.ResourceInfo{
 			Resource: corev1.ObjectReference{
 				Namespace:  resources[i].Resource.GetNamespace(),
 				Name:       resources[i].Resource.GetName(),
 				Kind:       resources[i].Resource.GetKind(),
 				APIVersion: resources[i].Resource.GetAPIVersion(),
 			},
-			Message: resources[i].Message,
+			Message:          resources[i].Message,
+			Diff:             resources[i].Diff,
+			PreviousReplicas: resources[i].PreviousReplicas,
 		}
 	}
-
-	return &reportSpec
+	return infos
 }
 
-// validateRollbackConfig ensures that a Cleaner with Rollback enabled also has a
+// ValidateRollbackConfig ensures that a Cleaner with Rollback enabled also has a
 // CleanerReport Notification configured, since that is what actually persists the
 // captured rollback data on the Report instance. Without it, Cleaner would delete
 // or transform resources while never being able to revert them.
-func validateRollbackConfig(cleaner *appsv1alpha1.Cleaner) error {
+func ValidateRollbackConfig(cleaner *appsv1alpha1.Cleaner) error {
 	if cleaner.Spec.Rollback == nil {
 		return nil
 	}
 
+	if len(cleaner.Spec.Actions) > 0 {
+		return fmt.Errorf("rollback is not supported for Cleaners with actions")
+	}
+
 	for i := range cleaner.Spec.Notifications {
 		if cleaner.Spec.Notifications[i].Type == appsv1alpha1.NotificationTypeCleanerReport {
 			return nil
@@ -177,11 +198,11 @@ func validateRollbackConfig(cleaner *appsv1alpha1.Cleaner) error {
 // to be deleted or transformed. It must be called, and succeed, before Cleaner
 // mutates any of those resources: otherwise a crash between the two steps would
 // leave resources changed with no way to revert them. It is a no-op unless the
-// Cleaner has Rollback configured.
+// Cleaner has Rollback configured, and in DryRun mode, where nothing is mutated.
 func persistRollbackSnapshot(ctx context.Context, cleaner *appsv1alpha1.Cleaner,
 	resources []ResourceResult, logger logr.Logger) error {
 
-	if cleaner.Spec.Rollback == nil || cleaner.Spec.Action == appsv1alpha1.ActionScan {
+	if cleaner.Spec.Rollback == nil || !capturesRollbackData(cleaner.Spec.Action) || isDryRun(cleaner) {
 		return nil
 	}
 
@@ -205,11 +226,11 @@ const (
 // is also marshaled into outgoing notification payloads (Slack, Teams, Discord,
 // Telegram, SMTP, Webex), and full resource bodies must never be sent there. It is
 // only meant for the Report instance itself, and only when the Cleaner has
-// Rollback configured.
+// Rollback configured and is not running in DryRun mode.
 func addRollbackResourceData(reportSpec *appsv1alpha1.ReportSpec, resources []ResourceResult,
 	cleaner *appsv1alpha1.Cleaner, logger logr.Logger) *appsv1alpha1.ReportSpec {
 
-	if cleaner.Spec.Rollback == nil || cleaner.Spec.Action == appsv1alpha1.ActionScan {
+	if cleaner.Spec.Rollback == nil || !capturesRollbackData(cleaner.Spec.Action) || isDryRun(cleaner) {
 		return reportSpec
 	}
 
@@ -401,12 +422,23 @@ func sendTelegramNotification(ctx context.Context, reportSpec *appsv1alpha1.Repo
 func sendKubernetesEventNotification(cleaner *appsv1alpha1.Cleaner, resources []ResourceResult) {
 	executorClient := GetClient()
 
+	action := cleanerAction(cleaner)
 	message := ""
-	switch cleaner.Spec.Action {
+	switch action {
 	case appsv1alpha1.ActionDelete:
 		message = fmt.Sprintf("resource deleted by Cleaner instance %s", cleaner.Name)
 	case appsv1alpha1.ActionTransform:
 		message = fmt.Sprintf("resource modified by Cleaner instance %s", cleaner.Name)
+	case appsv1alpha1.ActionLabel:
+		message = fmt.Sprintf("resource labeled by Cleaner instance %s", cleaner.Name)
+	case appsv1alpha1.ActionWebhook:
+		message = fmt.Sprintf("resource sent to webhook by Cleaner instance %s", cleaner.Name)
+	case appsv1alpha1.ActionScale:
+		message = fmt.Sprintf("resource scaled by Cleaner instance %s", cleaner.Name)
+	case appsv1alpha1.ActionEvict:
+		message = fmt.Sprintf("pod evicted by Cleaner instance %s", cleaner.Name)
+	case appsv1alpha1.ActionDrain:
+		message = fmt.Sprintf("node drained by Cleaner instance %s", cleaner.Name)
 	case appsv1alpha1.ActionScan:
 		message = fmt.Sprintf("resource matching Cleaner instance %s (current action Scan)", cleaner.Name)
 	}
@@ -414,10 +446,10 @@ func sendKubernetesEventNotification(cleaner *appsv1alpha1.Cleaner, resources []
 	for i := range resources {
 		if resources[i].Resource.GetNamespace() != "" {
 			executorClient.eventRecorder.Eventf(resources[i].Resource, nil, corev1.EventTypeNormal,
-				"K8sCleaner", string(cleaner.Spec.Action), fmt.Sprintf("[ns:%s] %s", resources[i].Resource.GetNamespace(), message))
+				"K8sCleaner", string(action), fmt.Sprintf("[ns:%s] %s", resources[i].Resource.GetNamespace(), message))
 		} else {
 			executorClient.eventRecorder.Eventf(resources[i].Resource, nil, corev1.EventTypeNormal,
-				"K8sCleaner", string(cleaner.Spec.Action), message)
+				"K8sCleaner", string(action), message)
 		}
 	}
 }
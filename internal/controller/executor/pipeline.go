@@ -0,0 +1,244 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	lua "github.com/yuin/gopher-lua"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+)
+
+// Outcomes of a step for a resource, as seen by the evaluate function of
+// later steps.
+const (
+	stepOutcomeProcessed = "processed"
+	stepOutcomeSkipped   = "skipped"
+	stepOutcomeFailed    = "failed"
+	stepOutcomeFiltered  = "filtered"
+)
+
+// stepOutcome is what a step did with one resource.
+type stepOutcome struct {
+	step    string
+	action  appsv1alpha1.Action
+	outcome string
+	message string
+}
+
+// cleanerAction returns the action reported for a run of cleaner: for a
+// Cleaner with Actions, the action of the last step.
+func cleanerAction(cleaner *appsv1alpha1.Cleaner) appsv1alpha1.Action {
+	if n := len(cleaner.Spec.Actions); n > 0 {
+		return cleaner.Spec.Actions[n-1].Action
+	}
+	return cleaner.Spec.Action
+}
+
+// takesAction returns false if cleaner only scans resources.
+func takesAction(cleaner *appsv1alpha1.Cleaner) bool {
+	if len(cleaner.Spec.Actions) == 0 {
+		return cleaner.Spec.Action != appsv1alpha1.ActionScan
+	}
+	for i := range cleaner.Spec.Actions {
+		if cleaner.Spec.Actions[i].Action != appsv1alpha1.ActionScan {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanerActionStep returns the step equivalent to the Action of a Cleaner
+// without Actions.
+func cleanerActionStep(cleaner *appsv1alpha1.Cleaner) *appsv1alpha1.ActionStep {
+	return &appsv1alpha1.ActionStep{
+		Name:             string(cleaner.Spec.Action),
+		Action:           cleaner.Spec.Action,
+		DeleteOptions:    cleaner.Spec.DeleteOptions,
+		Transform:        cleaner.Spec.Transform,
+		TransformOptions: cleaner.Spec.TransformOptions,
+		ScaleOptions:     cleaner.Spec.ScaleOptions,
+		EvictOptions:     cleaner.Spec.EvictOptions,
+		DrainOptions:     cleaner.Spec.DrainOptions,
+		LabelOptions:     cleaner.Spec.LabelOptions,
+		WebhookOptions:   cleaner.Spec.WebhookOptions,
+	}
+}
+
+// takeAction takes the Action of step on resources.
+func takeAction(ctx context.Context, cleaner *appsv1alpha1.Cleaner, step *appsv1alpha1.ActionStep,
+	resources []ResourceResult, dryRun bool, logger logr.Logger,
+) (processedResources, failedResources, skippedResources []ResourceResult, err error) {
+
+	switch step.Action {
+	case appsv1alpha1.ActionDelete:
+		return deleteResources(ctx, cleaner, step.DeleteOptions, resources, dryRun, logger)
+	case appsv1alpha1.ActionTransform:
+		return updateMatchingResources(ctx, cleaner.Name, resources, step.Transform, step.TransformOptions,
+			cleaner.Spec.LuaLimits, dryRun, logger)
+	case appsv1alpha1.ActionScale:
+		return scaleMatchingResources(ctx, cleaner.Name, resources, step.ScaleOptions, dryRun, logger)
+	case appsv1alpha1.ActionEvict:
+		return evictMatchingResources(ctx, cleaner.Name, resources, step.EvictOptions, dryRun, logger)
+	case appsv1alpha1.ActionDrain:
+		return drainMatchingResources(ctx, cleaner.Name, resources, step.DrainOptions, dryRun, logger)
+	case appsv1alpha1.ActionLabel:
+		return labelMatchingResources(ctx, cleaner.Name, resources, step.LabelOptions, dryRun, logger)
+	case appsv1alpha1.ActionWebhook:
+		return webhookMatchingResources(ctx, cleaner, step.WebhookOptions, resources, dryRun, logger)
+	case appsv1alpha1.ActionScan:
+		printMatchingResources(cleaner.Name, resources, logger)
+		return resources, nil, nil, nil
+	}
+
+	return nil, nil, nil, fmt.Errorf("unknown action %q", step.Action)
+}
+
+// runActionSteps takes the Actions of cleaner on resources, in order. Each
+// step acts on the resources its evaluate function selects among the ones no
+// earlier step failed on or removed. The processed, failed and skipped
+// resources returned are the ones of all steps, a resource being listed once
+// per step, the outcome of every step is returned as a StepReport, and errors
+// of all steps are joined.
+func runActionSteps(ctx context.Context, cleaner *appsv1alpha1.Cleaner, resources []ResourceResult,
+	dryRun bool, logger logr.Logger,
+) (processedResources, failedResources, skippedResources []ResourceResult,
+	stepReports []appsv1alpha1.StepReport, err error) {
+
+	var failedSteps []error // Slice to store all errors
+
+	// outcomes holds, by resource key, what each step did with the resource.
+	outcomes := make(map[string][]stepOutcome)
+	record := func(step *appsv1alpha1.ActionStep, results []ResourceResult, outcome string) {
+		for i := range results {
+			key := getResourceKey(results[i].Resource)
+			outcomes[key] = append(outcomes[key], stepOutcome{step: step.Name, action: step.Action,
+				outcome: outcome, message: results[i].Message})
+		}
+	}
+
+	current := resources
+	for i := range cleaner.Spec.Actions {
+		step := &cleaner.Spec.Actions[i]
+		l := logger.WithValues("step", step.Name)
+
+		// candidates are the resources passed to the next step, in order.
+		candidates := make([]ResourceResult, 0, len(current))
+		selected := make([]ResourceResult, 0, len(current))
+		filtered := make([]ResourceResult, 0)
+		var evaluateFailed []ResourceResult
+		for j := range current {
+			resource := current[j]
+			result, evalErr := runEvaluateWithGlobals(ctx, resource.Resource, step.Evaluate,
+				cleaner.Spec.LuaLimits, nil, nil, func(ls *lua.LState) {
+					setEvaluateGlobals(ls, nil, nil, nil, nil)
+					ls.SetGlobal("steps", stepsTable(ls, outcomes[getResourceKey(resource.Resource)]))
+				}, l)
+			if evalErr != nil {
+				evaluateFailed = append(evaluateFailed, ResourceResult{Resource: resource.Resource,
+					Message: evalErr.Error()})
+				failedSteps = append(failedSteps, fmt.Errorf("step %s: %s %s/%s: %w", step.Name,
+					resource.Resource.GetKind(), resource.Resource.GetNamespace(), resource.Resource.GetName(),
+					evalErr))
+				continue
+			}
+			if !result.Matching {
+				filtered = append(filtered, resource)
+				candidates = append(candidates, resource)
+				continue
+			}
+			if result.Message != "" {
+				resource.Message = result.Message
+			}
+			if result.Replicas != nil {
+				resource.Replicas = result.Replicas
+			}
+			selected = append(selected, resource)
+			candidates = append(candidates, resource)
+		}
+
+		l.V(logs.LogDebug).Info(fmt.Sprintf("taking action %s on %d resource(s)", step.Action, len(selected)))
+		stepProcessed, stepFailed, stepSkipped, stepErr := takeAction(ctx, cleaner, step, selected, dryRun, l)
+		if stepErr != nil {
+			failedSteps = append(failedSteps, fmt.Errorf("step %s: %w", step.Name, stepErr))
+		}
+		stepFailed = append(evaluateFailed, stepFailed...)
+
+		stepReports = append(stepReports, appsv1alpha1.StepReport{
+			Name:             step.Name,
+			Action:           step.Action,
+			ResourceInfo:     resourceInfos(stepProcessed),
+			SkippedResources: resourceInfos(stepSkipped),
+			FailedResources:  resourceInfos(stepFailed),
+		})
+
+		processedResources = append(processedResources, stepProcessed...)
+		failedResources = append(failedResources, stepFailed...)
+		skippedResources = append(skippedResources, stepSkipped...)
+
+		record(step, stepProcessed, stepOutcomeProcessed)
+		record(step, stepSkipped, stepOutcomeSkipped)
+		record(step, stepFailed, stepOutcomeFailed)
+		record(step, filtered, stepOutcomeFiltered)
+
+		// Resources this step failed on, or removed, are not passed to later
+		// steps. Selected resources carry the message and replicas this step's
+		// evaluate set.
+		dropped := make(map[string]bool, len(stepFailed))
+		for j := range stepFailed {
+			dropped[getResourceKey(stepFailed[j].Resource)] = true
+		}
+		for j := range stepProcessed {
+			if stepProcessed[j].Removed {
+				dropped[getResourceKey(stepProcessed[j].Resource)] = true
+			}
+		}
+		current = make([]ResourceResult, 0, len(candidates))
+		for j := range candidates {
+			if !dropped[getResourceKey(candidates[j].Resource)] {
+				current = append(current, candidates[j])
+			}
+		}
+	}
+
+	if len(failedSteps) > 0 {
+		// Use errors.Join to combine all collected errors into a single error
+		return processedResources, failedResources, skippedResources, stepReports, errors.Join(failedSteps...)
+	}
+
+	return processedResources, failedResources, skippedResources, stepReports, nil
+}
+
+// stepsTable returns the Lua table, keyed by step name, describing the
+// outcome of earlier steps for a resource.
+func stepsTable(l *lua.LState, outcomes []stepOutcome) *lua.LTable {
+	steps := l.NewTable()
+	for i := range outcomes {
+		entry := l.NewTable()
+		entry.RawSetString("action", lua.LString(outcomes[i].action))
+		entry.RawSetString("outcome", lua.LString(outcomes[i].outcome))
+		entry.RawSetString("message", lua.LString(outcomes[i].message))
+		steps.RawSetString(outcomes[i].step, entry)
+	}
+	return steps
+}
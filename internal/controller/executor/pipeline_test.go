@@ -0,0 +1,223 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor_test
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+	"gianlucam76/k8s-cleaner/internal/controller/executor"
+)
+
+var _ = Describe("Action pipelines", func() {
+	var ns *corev1.Namespace
+
+	BeforeEach(func() {
+		ns = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString()},
+		}
+		Expect(k8sClient.Create(context.TODO(), ns)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, ns)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), ns)).To(Succeed())
+	})
+
+	createConfigMap := func(labels map[string]string) executor.ResourceResult {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ns.Name,
+				Name:      randomString(),
+				Labels:    labels,
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), cm)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, cm)).To(Succeed())
+
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cm)
+		Expect(err).To(BeNil())
+		u := &unstructured.Unstructured{Object: content}
+		u.SetAPIVersion("v1")
+		u.SetKind("ConfigMap")
+		return executor.ResourceResult{Resource: u, Message: "unused"}
+	}
+
+	getConfigMap := func(resource executor.ResourceResult) (*corev1.ConfigMap, error) {
+		cm := &corev1.ConfigMap{}
+		err := k8sClient.Get(context.TODO(), types.NamespacedName{
+			Namespace: resource.Resource.GetNamespace(), Name: resource.Resource.GetName()}, cm)
+		return cm, err
+	}
+
+	newCleaner := func(actions ...appsv1alpha1.ActionStep) *appsv1alpha1.Cleaner {
+		return &appsv1alpha1.Cleaner{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString()},
+			Spec:       appsv1alpha1.CleanerSpec{Actions: actions},
+		}
+	}
+
+	It("runs steps in order, each on the resources its evaluate function selects", func() {
+		keep := createConfigMap(map[string]string{"keep": "true"})
+		remove := createConfigMap(nil)
+
+		cleaner := newCleaner(
+			appsv1alpha1.ActionStep{
+				Name:   "annotate",
+				Action: appsv1alpha1.ActionLabel,
+				LabelOptions: &appsv1alpha1.LabelOptions{
+					AddAnnotations: map[string]string{"cleaner/reason": "{{ .Message }}"},
+				},
+			},
+			appsv1alpha1.ActionStep{
+				Name:   "delete",
+				Action: appsv1alpha1.ActionDelete,
+				Evaluate: `function evaluate()
+					hs = {}
+					hs.matching = steps.annotate.outcome == "processed" and
+						(obj.metadata.labels == nil or obj.metadata.labels.keep ~= "true")
+					return hs
+				end`,
+			},
+		)
+
+		processed, failed, skipped, steps, err := executor.RunActionSteps(context.TODO(), cleaner,
+			[]executor.ResourceResult{keep, remove}, false, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(failed).To(BeEmpty())
+		Expect(skipped).To(BeEmpty())
+		// Both resources were annotated, one was deleted.
+		Expect(processed).To(HaveLen(3))
+		Expect(processed[2].Resource.GetName()).To(Equal(remove.Resource.GetName()))
+
+		Expect(steps).To(HaveLen(2))
+		Expect(steps[0].Name).To(Equal("annotate"))
+		Expect(steps[0].Action).To(Equal(appsv1alpha1.ActionLabel))
+		Expect(steps[0].ResourceInfo).To(HaveLen(2))
+		Expect(steps[1].Name).To(Equal("delete"))
+		Expect(steps[1].Action).To(Equal(appsv1alpha1.ActionDelete))
+		Expect(steps[1].ResourceInfo).To(HaveLen(1))
+
+		cm, err := getConfigMap(keep)
+		Expect(err).To(BeNil())
+		Expect(cm.Annotations["cleaner/reason"]).To(Equal("unused"))
+
+		Eventually(func() bool {
+			_, err := getConfigMap(remove)
+			return apierrors.IsNotFound(err)
+		}, timeout, pollingInterval).Should(BeTrue())
+	})
+
+	It("does not pass resources a step failed on to later steps", func() {
+		good := createConfigMap(nil)
+		bad := createConfigMap(nil)
+
+		cleaner := newCleaner(
+			appsv1alpha1.ActionStep{
+				Name:   "check",
+				Action: appsv1alpha1.ActionScan,
+				Evaluate: `function evaluate()
+					if obj.metadata.name == "` + bad.Resource.GetName() + `" then
+						error("cannot evaluate")
+					end
+					hs = {}
+					hs.matching = true
+					hs.message = "checked"
+					return hs
+				end`,
+			},
+			appsv1alpha1.ActionStep{
+				Name:   "label",
+				Action: appsv1alpha1.ActionLabel,
+				LabelOptions: &appsv1alpha1.LabelOptions{
+					AddLabels: map[string]string{"checked": "{{ .Message }}"},
+				},
+			},
+		)
+
+		processed, _, _, steps, err := executor.RunActionSteps(context.TODO(), cleaner,
+			[]executor.ResourceResult{good, bad}, false, logr.Discard())
+		Expect(err).ToNot(BeNil())
+		Expect(processed).To(HaveLen(2))
+		Expect(processed[0].Resource.GetName()).To(Equal(good.Resource.GetName()))
+		Expect(processed[1].Resource.GetName()).To(Equal(good.Resource.GetName()))
+
+		Expect(steps).To(HaveLen(2))
+		Expect(steps[0].FailedResources).To(HaveLen(1))
+		Expect(steps[0].FailedResources[0].Resource.Name).To(Equal(bad.Resource.GetName()))
+
+		cm, err := getConfigMap(good)
+		Expect(err).To(BeNil())
+		Expect(cm.Labels["checked"]).To(Equal("checked"))
+
+		cm, err = getConfigMap(bad)
+		Expect(err).To(BeNil())
+		Expect(cm.Labels).ToNot(HaveKey("checked"))
+	})
+
+	It("does not pass resources a step removed to later steps", func() {
+		remove := createConfigMap(map[string]string{"remove": "true"})
+		keep := createConfigMap(nil)
+
+		cleaner := newCleaner(
+			appsv1alpha1.ActionStep{
+				Name:   "delete",
+				Action: appsv1alpha1.ActionDelete,
+				Evaluate: `function evaluate()
+					hs = {}
+					hs.matching = obj.metadata.labels ~= nil and obj.metadata.labels.remove == "true"
+					return hs
+				end`,
+			},
+			appsv1alpha1.ActionStep{
+				Name:   "label",
+				Action: appsv1alpha1.ActionLabel,
+				LabelOptions: &appsv1alpha1.LabelOptions{
+					AddLabels: map[string]string{"kept": "true"},
+				},
+			},
+		)
+
+		processed, failed, skipped, steps, err := executor.RunActionSteps(context.TODO(), cleaner,
+			[]executor.ResourceResult{remove, keep}, false, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(failed).To(BeEmpty())
+		Expect(skipped).To(BeEmpty())
+		Expect(processed).To(HaveLen(2))
+
+		Expect(steps).To(HaveLen(2))
+		Expect(steps[0].ResourceInfo).To(HaveLen(1))
+		Expect(steps[0].ResourceInfo[0].Resource.Name).To(Equal(remove.Resource.GetName()))
+		Expect(steps[1].ResourceInfo).To(HaveLen(1))
+		Expect(steps[1].ResourceInfo[0].Resource.Name).To(Equal(keep.Resource.GetName()))
+
+		cm, err := getConfigMap(keep)
+		Expect(err).To(BeNil())
+		Expect(cm.Labels["kept"]).To(Equal("true"))
+	})
+})
@@ -179,17 +179,24 @@ func parsePromValue(raw json.RawMessage) (float64, error) {
 // and returns the appropriate Authorization header value, or empty string when
 // no SecretRef is set.
 func buildAuthHeader(ctx context.Context, source *appsv1alpha1.MetricSource) (string, error) {
-	if source.SecretRef == nil {
+	return authHeaderFromSecret(ctx, source.SecretRef)
+}
+
+// authHeaderFromSecret returns the Authorization header value built from the
+// credentials in the Secret referenced by secretRef, or empty string when
+// secretRef is nil.
+func authHeaderFromSecret(ctx context.Context, secretRef *corev1.SecretReference) (string, error) {
+	if secretRef == nil {
 		return "", nil
 	}
 
 	secret := &corev1.Secret{}
 	if err := k8sClient.Get(ctx, types.NamespacedName{
-		Namespace: source.SecretRef.Namespace,
-		Name:      source.SecretRef.Name,
+		Namespace: secretRef.Namespace,
+		Name:      secretRef.Name,
 	}, secret); err != nil {
 		return "", fmt.Errorf("getting Secret %s/%s: %w",
-			source.SecretRef.Namespace, source.SecretRef.Name, err)
+			secretRef.Namespace, secretRef.Name, err)
 	}
 
 	if token, ok := secret.Data["token"]; ok {
@@ -205,5 +212,5 @@ func buildAuthHeader(ctx context.Context, source *appsv1alpha1.MetricSource) (st
 	}
 
 	return "", fmt.Errorf("secret %s/%s must contain key \"token\" or keys \"username\" and \"password\"",
-		source.SecretRef.Namespace, source.SecretRef.Name)
+		secretRef.Namespace, secretRef.Name)
 }
@@ -0,0 +1,185 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+)
+
+var (
+	protectionMu sync.RWMutex
+	// protectedNamespaces are namespaces no Cleaner Action but Scan can affect.
+	protectedNamespaces = map[string]bool{}
+	// protectedNamespaceSelectors select namespaces no Cleaner Action but
+	// Scan can affect.
+	protectedNamespaceSelectors []labels.Selector
+)
+
+// SetProtectedNamespaces sets the controller-wide list of protected
+// namespaces, by name and by label selector. No Action but Scan is ever taken
+// on resources in those namespaces, whatever Cleaner selects them.
+func SetProtectedNamespaces(namespaces, selectors []string) error {
+	parsed := make([]labels.Selector, 0, len(selectors))
+	for i := range selectors {
+		selector, err := labels.Parse(selectors[i])
+		if err != nil {
+			return fmt.Errorf("invalid protected namespace selector %q: %w", selectors[i], err)
+		}
+		parsed = append(parsed, selector)
+	}
+
+	names := make(map[string]bool, len(namespaces))
+	for i := range namespaces {
+		names[namespaces[i]] = true
+	}
+
+	protectionMu.Lock()
+	defer protectionMu.Unlock()
+	protectedNamespaces = names
+	protectedNamespaceSelectors = parsed
+	return nil
+}
+
+// skipProtectedResources splits resources into the ones an Action of Cleaner
+// cleanerName can be taken on and the ones it must skip, either because they
+// are in a protected namespace or because they carry the protect annotation.
+// The Message of a skipped resource explains why it was skipped.
+func skipProtectedResources(ctx context.Context, cleanerName string, resources []ResourceResult,
+	logger logr.Logger) (allowed, skipped []ResourceResult) {
+
+	protectionMu.RLock()
+	names := protectedNamespaces
+	selectors := protectedNamespaceSelectors
+	protectionMu.RUnlock()
+
+	allowed = make([]ResourceResult, 0, len(resources))
+	// namespaceLabels caches labels of namespaces already fetched. A nil
+	// entry means the namespace does not exist.
+	namespaceLabels := make(map[string]labels.Set)
+	for i := range resources {
+		namespace := resources[i].Resource.GetNamespace()
+
+		reason := protectedByAnnotation(resources[i].Resource, cleanerName, time.Now())
+		switch {
+		case reason != "", namespace == "":
+		case names[namespace]:
+			reason = fmt.Sprintf("namespace %s is protected", namespace)
+		case len(selectors) > 0:
+			nsLabels, ok := namespaceLabels[namespace]
+			if !ok {
+				var err error
+				nsLabels, err = getNamespaceLabels(ctx, namespace)
+				if err != nil {
+					// When in doubt, leave the resource alone.
+					reason = fmt.Sprintf("failed to verify whether namespace %s is protected: %v", namespace, err)
+					break
+				}
+				namespaceLabels[namespace] = nsLabels
+			}
+			for j := range selectors {
+				if nsLabels != nil && selectors[j].Matches(nsLabels) {
+					reason = fmt.Sprintf("namespace %s is protected", namespace)
+					break
+				}
+			}
+		}
+
+		if reason == "" {
+			allowed = append(allowed, resources[i])
+			continue
+		}
+
+		logger.V(logs.LogInfo).Info(fmt.Sprintf("skipping %s:%s/%s: %s", resources[i].Resource.GetKind(),
+			namespace, resources[i].Resource.GetName(), reason))
+		skipped = append(skipped, ResourceResult{Resource: resources[i].Resource, Message: reason})
+	}
+
+	return allowed, skipped
+}
+
+// protectedByAnnotation returns why resource is protected from Cleaner
+// cleanerName by its annotations at time now, an empty string if it is not.
+func protectedByAnnotation(resource *unstructured.Unstructured, cleanerName string, now time.Time) string {
+	annotations := resource.GetAnnotations()
+	value, ok := annotations[appsv1alpha1.ProtectAnnotation]
+	if !ok {
+		return ""
+	}
+
+	value = strings.TrimSpace(value)
+	if strings.EqualFold(value, "false") || value == "" {
+		return ""
+	}
+	if !strings.EqualFold(value, "true") {
+		protectedFrom := false
+		for _, name := range strings.Split(value, ",") {
+			if strings.TrimSpace(name) == cleanerName {
+				protectedFrom = true
+				break
+			}
+		}
+		if !protectedFrom {
+			return ""
+		}
+	}
+
+	if until, ok := annotations[appsv1alpha1.ProtectUntilAnnotation]; ok {
+		expiry, err := time.Parse(time.RFC3339, until)
+		if err != nil {
+			// A malformed expiry must not remove the protection.
+			return fmt.Sprintf("protected by %s annotation (invalid %s %q)",
+				appsv1alpha1.ProtectAnnotation, appsv1alpha1.ProtectUntilAnnotation, until)
+		}
+		if !now.Before(expiry) {
+			return ""
+		}
+		return fmt.Sprintf("protected by %s annotation until %s", appsv1alpha1.ProtectAnnotation,
+			expiry.Format(time.RFC3339))
+	}
+
+	return fmt.Sprintf("protected by %s annotation", appsv1alpha1.ProtectAnnotation)
+}
+
+// getNamespaceLabels returns the labels of namespace, nil if it does not exist.
+func getNamespaceLabels(ctx context.Context, namespace string) (labels.Set, error) {
+	ns := &corev1.Namespace{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if ns.Labels == nil {
+		return labels.Set{}, nil
+	}
+	return labels.Set(ns.Labels), nil
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+	"gianlucam76/k8s-cleaner/internal/controller/executor"
+)
+
+var _ = Describe("Namespace protection", func() {
+	var ns *corev1.Namespace
+	var protectedLabel string
+
+	BeforeEach(func() {
+		protectedLabel = randomString()
+		ns = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   randomString(),
+				Labels: map[string]string{protectedLabel: "true"},
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), ns)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, ns)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(executor.SetProtectedNamespaces(nil, nil)).To(Succeed())
+		Expect(k8sClient.Delete(context.TODO(), ns)).To(Succeed())
+	})
+
+	createConfigMap := func() *unstructured.Unstructured {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name, Name: randomString()},
+		}
+		Expect(k8sClient.Create(context.TODO(), cm)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, cm)).To(Succeed())
+
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cm)
+		Expect(err).To(BeNil())
+		u := &unstructured.Unstructured{Object: content}
+		u.SetAPIVersion(apiVersionV1)
+		u.SetKind(kindConfigMap)
+		return u
+	}
+
+	It("deleteMatchingResources skips resources in namespaces protected by name", func() {
+		u := createConfigMap()
+		Expect(executor.SetProtectedNamespaces([]string{ns.Name}, nil)).To(Succeed())
+
+		processed, failed, skipped, err := executor.DeleteMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{{Resource: u}}, nil, false, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(processed).To(BeEmpty())
+		Expect(failed).To(BeEmpty())
+		Expect(skipped).To(HaveLen(1))
+		Expect(skipped[0].Message).To(ContainSubstring("protected"))
+
+		currentCm := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(context.TODO(),
+			types.NamespacedName{Namespace: u.GetNamespace(), Name: u.GetName()}, currentCm)).To(Succeed())
+	})
+
+	It("updateMatchingResources skips resources in namespaces protected by label selector", func() {
+		u := createConfigMap()
+		Expect(executor.SetProtectedNamespaces(nil, []string{protectedLabel + "=true"})).To(Succeed())
+
+		processed, _, skipped, err := executor.UpdateMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{{Resource: u}}, transformAddLabel, nil, nil, false, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(processed).To(BeEmpty())
+		Expect(skipped).To(HaveLen(1))
+
+		currentCm := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(context.TODO(),
+			types.NamespacedName{Namespace: u.GetNamespace(), Name: u.GetName()}, currentCm)).To(Succeed())
+		Expect(currentCm.Labels).ToNot(HaveKey("dry-run"))
+	})
+
+	It("deleteMatchingResources skips resources carrying the protect annotation", func() {
+		u := createConfigMap()
+		u.SetAnnotations(map[string]string{appsv1alpha1.ProtectAnnotation: "true"})
+
+		processed, _, skipped, err := executor.DeleteMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{{Resource: u}}, nil, false, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(processed).To(BeEmpty())
+		Expect(skipped).To(HaveLen(1))
+		Expect(skipped[0].Message).To(ContainSubstring(appsv1alpha1.ProtectAnnotation))
+
+		currentCm := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(context.TODO(),
+			types.NamespacedName{Namespace: u.GetNamespace(), Name: u.GetName()}, currentCm)).To(Succeed())
+	})
+
+	It("SetProtectedNamespaces rejects invalid label selectors", func() {
+		Expect(executor.SetProtectedNamespaces(nil, []string{"a in (b"})).ToNot(Succeed())
+	})
+
+	It("fetchResources skips resources in excluded namespaces", func() {
+		createConfigMap()
+
+		selector := &appsv1alpha1.ResourceSelector{
+			Kind:      kindConfigMap,
+			Group:     "",
+			Version:   apiVersionV1,
+			Namespace: ns.Name,
+		}
+		list, err := executor.FetchResources(context.TODO(), selector, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(list).ToNot(BeEmpty())
+
+		selector.ExcludeNamespaces = []string{ns.Name}
+		list, err = executor.FetchResources(context.TODO(), selector, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(list).To(BeEmpty())
+
+		selector.ExcludeNamespaces = nil
+		selector.ExcludeNamespaceSelector = protectedLabel + "=true"
+		list, err = executor.FetchResources(context.TODO(), selector, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(list).To(BeEmpty())
+	})
+})
+
+var _ = DescribeTable("protectedByAnnotation",
+	func(annotations map[string]string, protected bool) {
+		now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		u := &unstructured.Unstructured{}
+		u.SetAnnotations(annotations)
+		reason := executor.ProtectedByAnnotation(u, "cleaner-a", now)
+		Expect(reason != "").To(Equal(protected))
+	},
+	Entry("no annotation", nil, false),
+	Entry("protected from every Cleaner",
+		map[string]string{appsv1alpha1.ProtectAnnotation: "true"}, true),
+	Entry("explicitly not protected",
+		map[string]string{appsv1alpha1.ProtectAnnotation: "false"}, false),
+	Entry("protected from this Cleaner",
+		map[string]string{appsv1alpha1.ProtectAnnotation: "cleaner-b, cleaner-a"}, true),
+	Entry("protected from other Cleaners only",
+		map[string]string{appsv1alpha1.ProtectAnnotation: "cleaner-b,cleaner-c"}, false),
+	Entry("protection not expired yet",
+		map[string]string{appsv1alpha1.ProtectAnnotation: "true",
+			appsv1alpha1.ProtectUntilAnnotation: "2026-02-01T00:00:00Z"}, true),
+	Entry("protection expired",
+		map[string]string{appsv1alpha1.ProtectAnnotation: "true",
+			appsv1alpha1.ProtectUntilAnnotation: "2025-12-01T00:00:00Z"}, false),
+	Entry("invalid expiry keeps the protection",
+		map[string]string{appsv1alpha1.ProtectAnnotation: "true",
+			appsv1alpha1.ProtectUntilAnnotation: "tomorrow"}, true),
+)
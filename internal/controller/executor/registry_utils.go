@@ -27,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 
 	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
 )
@@ -168,16 +169,137 @@ func updateRegistry(ctx context.Context, cleaner *appsv1alpha1.Cleaner,
 	return k8sClient.Update(ctx, configMap)
 }
 
-// 5. Cleanup Logic
-// DeleteConfigMap removes the registry ConfigMap from the cluster.
-func DeleteConfigMap(ctx context.Context, cleaner *appsv1alpha1.Cleaner) error {
+// getCleanerConfigMapInfo returns the name and namespace of the ConfigMap
+// recording, for cleaner, the per-resource state identified by suffix.
+func getCleanerConfigMapInfo(cleaner *appsv1alpha1.Cleaner, suffix string) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: os.Getenv(namespace),
+		Name:      fmt.Sprintf("cleaner-%s-%s", cleaner.Name, suffix),
+	}
+}
+
+// getCleanerConfigMapData returns the Data of the ConfigMap identified by
+// suffix, keyed by resource key. It is empty if the ConfigMap does not exist.
+func getCleanerConfigMapData(ctx context.Context, cleaner *appsv1alpha1.Cleaner, suffix string,
+) (map[string]string, error) {
+
 	configMap := &corev1.ConfigMap{}
-	err := k8sClient.Get(ctx, getConfigMapInfo(cleaner), configMap)
+	err := k8sClient.Get(ctx, getCleanerConfigMapInfo(cleaner, suffix), configMap)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+
+	if configMap.Data == nil {
+		return map[string]string{}, nil
+	}
+	return configMap.Data, nil
+}
+
+// updateCleanerConfigMapData persists data in the ConfigMap identified by
+// suffix, replacing previously recorded data. The ConfigMap is owned by
+// cleaner, and only created when there is data to record.
+func updateCleanerConfigMapData(ctx context.Context, cleaner *appsv1alpha1.Cleaner, suffix string,
+	data map[string]string) error {
+
+	info := getCleanerConfigMapInfo(cleaner, suffix)
+	configMap := &corev1.ConfigMap{}
+	err := k8sClient.Get(ctx, info, configMap)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		if len(data) == 0 {
 			return nil
 		}
-		return err
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      info.Name,
+				Namespace: info.Namespace,
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(cleaner, appsv1alpha1.GroupVersion.WithKind("Cleaner")),
+				},
+			},
+			Data: data,
+		}
+		return k8sClient.Create(ctx, configMap)
+	}
+
+	configMap.Data = data
+	return k8sClient.Update(ctx, configMap)
+}
+
+// patchCleanerConfigMapData sets the keys in set, and removes the keys in
+// remove, of the ConfigMap identified by suffix, leaving the other keys alone.
+// Runs of the same Cleaner updating different keys concurrently, such as a
+// scheduled run and a run on a changed resource, do not overwrite each other.
+func patchCleanerConfigMapData(ctx context.Context, cleaner *appsv1alpha1.Cleaner, suffix string,
+	set map[string]string, remove []string) error {
+
+	if len(set) == 0 && len(remove) == 0 {
+		return nil
+	}
+
+	info := getCleanerConfigMapInfo(cleaner, suffix)
+	return retry.OnError(retry.DefaultBackoff, func(err error) bool {
+		return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+	}, func() error {
+		configMap := &corev1.ConfigMap{}
+		err := k8sClient.Get(ctx, info, configMap)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			if len(set) == 0 {
+				return nil
+			}
+			configMap = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      info.Name,
+					Namespace: info.Namespace,
+					OwnerReferences: []metav1.OwnerReference{
+						*metav1.NewControllerRef(cleaner, appsv1alpha1.GroupVersion.WithKind("Cleaner")),
+					},
+				},
+				Data: set,
+			}
+			return k8sClient.Create(ctx, configMap)
+		}
+
+		if configMap.Data == nil {
+			configMap.Data = make(map[string]string, len(set))
+		}
+		for key, value := range set {
+			configMap.Data[key] = value
+		}
+		for i := range remove {
+			delete(configMap.Data, remove[i])
+		}
+		return k8sClient.Update(ctx, configMap)
+	})
+}
+
+// 5. Cleanup Logic
+// DeleteConfigMap removes the registry ConfigMap, and the ones recording the
+// resources marked by a two-phase Delete and the ones deferred by
+// maxActionsPerRun, from the cluster.
+func DeleteConfigMap(ctx context.Context, cleaner *appsv1alpha1.Cleaner) error {
+	for _, info := range []types.NamespacedName{getConfigMapInfo(cleaner),
+		getCleanerConfigMapInfo(cleaner, deletionMarksSuffix),
+		getCleanerConfigMapInfo(cleaner, deferredResourcesSuffix)} {
+		configMap := &corev1.ConfigMap{}
+		err := k8sClient.Get(ctx, info, configMap)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if err := k8sClient.Delete(ctx, configMap); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
 	}
-	return k8sClient.Delete(ctx, configMap)
+	return nil
 }
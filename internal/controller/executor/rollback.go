@@ -41,11 +41,11 @@ type RollbackResourceResult struct {
 	Message   string `json:"message,omitempty"`
 }
 
-// Rollback reverts the most recent Delete or Transform execution recorded for
-// cleanerName, using the pre-action resource state captured in the Report
-// instance's ResourceInfo.FullResource. It is a best-effort, per-resource
-// operation: a failure on one resource does not stop the others from being
-// attempted.
+// Rollback reverts the most recent Delete, Transform, Label or Scale execution
+// recorded for cleanerName, using the pre-action resource state captured in
+// the Report instance's ResourceInfo.FullResource (PreviousReplicas for
+// Scale). It is a best-effort, per-resource operation: a failure on one
+// resource does not stop the others from being attempted.
 func Rollback(ctx context.Context, c client.Client, cleanerName string, logger logr.Logger,
 ) ([]RollbackResourceResult, error) {
 
@@ -54,8 +54,20 @@ func Rollback(ctx context.Context, c client.Client, cleanerName string, logger l
 		return nil, err
 	}
 
-	if report.Spec.Action == appsv1alpha1.ActionScan {
-		return nil, fmt.Errorf("nothing to roll back: last execution's action was Scan")
+	if len(report.Spec.Steps) > 0 {
+		return nil, fmt.Errorf("nothing to roll back: rollback is not supported for Cleaners with actions")
+	}
+
+	switch report.Spec.Action {
+	case appsv1alpha1.ActionScan, appsv1alpha1.ActionWebhook:
+		return nil, fmt.Errorf("nothing to roll back: last execution's action was %s", report.Spec.Action)
+	case appsv1alpha1.ActionEvict, appsv1alpha1.ActionDrain:
+		return nil, fmt.Errorf("nothing to roll back: last execution's action was %s, "+
+			"evicted Pods are recreated by their controllers", report.Spec.Action)
+	}
+
+	if report.Spec.DryRun {
+		return nil, fmt.Errorf("nothing to roll back: last execution was a dry run")
 	}
 
 	results := make([]RollbackResourceResult, 0, len(report.Spec.ResourceInfo))
@@ -67,6 +79,18 @@ func Rollback(ctx context.Context, c client.Client, cleanerName string, logger l
 	return results, nil
 }
 
+// capturesRollbackData returns whether the state of resources is captured
+// before action is taken on them. Scan, Evict, Drain and Webhook actions
+// cannot be rolled back.
+func capturesRollbackData(action appsv1alpha1.Action) bool {
+	switch action {
+	case appsv1alpha1.ActionScan, appsv1alpha1.ActionEvict, appsv1alpha1.ActionDrain, appsv1alpha1.ActionWebhook:
+		return false
+	default:
+		return true
+	}
+}
+
 func rollbackResource(ctx context.Context, c client.Client, action appsv1alpha1.Action,
 	resourceInfo *appsv1alpha1.ResourceInfo, logger logr.Logger) RollbackResourceResult {
 
@@ -77,6 +101,28 @@ func rollbackResource(ctx context.Context, c client.Client, action appsv1alpha1.
 		Name:      ref.Name,
 	}
 
+	l := logger.WithValues("resource", fmt.Sprintf("%s:%s/%s", ref.Kind, ref.Namespace, ref.Name))
+
+	if action == appsv1alpha1.ActionScale {
+		// Only the replica count was changed, and it is always recorded.
+		if resourceInfo.PreviousReplicas == nil {
+			result.Message = "no previous replica count recorded for this resource"
+			return result
+		}
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(ref.APIVersion)
+		obj.SetKind(ref.Kind)
+		obj.SetNamespace(ref.Namespace)
+		obj.SetName(ref.Name)
+		if _, err := scaleResource(ctx, c, obj, *resourceInfo.PreviousReplicas, false); err != nil {
+			l.V(logs.LogInfo).Info(fmt.Sprintf("failed to roll back resource: %v", err))
+			result.Message = err.Error()
+			return result
+		}
+		result.Success = true
+		return result
+	}
+
 	if len(resourceInfo.FullResource) == 0 {
 		result.Message = "no rollback data captured for this resource"
 		return result
@@ -92,16 +138,16 @@ func rollbackResource(ctx context.Context, c client.Client, action appsv1alpha1.
 		obj.SetKind(ref.Kind)
 	}
 
-	l := logger.WithValues("resource", fmt.Sprintf("%s:%s/%s", ref.Kind, ref.Namespace, ref.Name))
-
 	var err error
 	switch action {
 	case appsv1alpha1.ActionDelete:
 		err = recreateResource(ctx, c, obj)
-	case appsv1alpha1.ActionTransform:
+	case appsv1alpha1.ActionTransform, appsv1alpha1.ActionLabel:
 		err = restoreResource(ctx, c, obj)
-	case appsv1alpha1.ActionScan:
-		// Nothing is ever captured for a Scan action.
+	case appsv1alpha1.ActionScan, appsv1alpha1.ActionEvict, appsv1alpha1.ActionDrain, appsv1alpha1.ActionWebhook,
+		appsv1alpha1.ActionScale:
+		// Nothing is ever captured for a Scan, Evict, Drain or Webhook action.
+		// Scale is handled above.
 	}
 
 	if err != nil {
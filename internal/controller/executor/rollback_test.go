@@ -104,7 +104,7 @@ var _ = Describe("Rollback capture", func() {
 		Expect(reportSpec.ResourceInfo[0].Message).To(ContainSubstring("rollback data not stored"))
 	})
 
-	It("validateRollbackConfig requires a CleanerReport notification when Rollback is enabled", func() {
+	It("ValidateRollbackConfig requires a CleanerReport notification when Rollback is enabled", func() {
 		withRollbackNoNotification := newRollbackCleaner(randomString(), appsv1alpha1.ActionDelete,
 			&appsv1alpha1.RollbackOptions{Storage: appsv1alpha1.RollbackStorageReport})
 		Expect(executor.ValidateRollbackConfig(withRollbackNoNotification)).ToNot(Succeed())
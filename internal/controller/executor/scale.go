@@ -0,0 +1,135 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+)
+
+const scaleSubresource = "scale"
+
+// scaleMatchingResources changes the replica count of resources through their
+// scale subresource. The target count is the one returned by the evaluate
+// function, if any, scaleOptions.Replicas otherwise. Processed resources carry
+// the replica count they had before.
+func scaleMatchingResources(ctx context.Context, cleanerName string, resources []ResourceResult,
+	scaleOptions *appsv1alpha1.ScaleOptions, dryRun bool, logger logr.Logger,
+) (processedResources, failedResources, skippedResources []ResourceResult, err error) {
+
+	processedResources = make([]ResourceResult, 0)
+	var failedActions []error // Slice to store all errors
+
+	resources, skippedResources = skipProtectedResources(ctx, cleanerName, resources, logger)
+
+	if !dryRun {
+		reportUpdatedCount(cleanerName, float64(len(resources)))
+	}
+
+	numberOfErrors := 0
+	for i := range resources {
+		resource := resources[i]
+		l := logger.WithValues("resource", fmt.Sprintf("%s:%s/%s",
+			resource.Resource.GetKind(),
+			resource.Resource.GetNamespace(),
+			resource.Resource.GetName()))
+
+		replicas := resource.Replicas
+		if replicas == nil && scaleOptions != nil {
+			replicas = scaleOptions.Replicas
+		}
+		var scaleErr error
+		if replicas == nil {
+			scaleErr = errors.New("no replica count: set scaleOptions.replicas or return replicas from evaluate")
+		} else {
+			waitForAction(ctx)
+			l.Info(fmt.Sprintf("scaling resource to %d replicas", *replicas))
+			resource.PreviousReplicas, scaleErr = scaleResource(ctx, k8sClient, resource.Resource, *replicas, dryRun)
+		}
+		if scaleErr != nil {
+			if apierrors.IsNotFound(scaleErr) {
+				continue
+			}
+			numberOfErrors++
+			reportErrorEvent(cleanerName, resource.Resource.GetAPIVersion(),
+				resource.Resource.GetKind())
+			l.Info(fmt.Sprintf("failed to scale resource: %v", scaleErr))
+			failedActions = append(failedActions, fmt.Errorf("%s %s/%s: %w", resource.Resource.GetKind(),
+				resource.Resource.GetNamespace(), resource.Resource.GetName(), scaleErr))
+			failedResources = append(failedResources, ResourceResult{Resource: resource.Resource,
+				Message: scaleErr.Error()})
+			continue
+		}
+
+		resource.Replicas = replicas
+		processedResources = append(processedResources, resource)
+		if !dryRun {
+			reportUpdateEvent(cleanerName, resource.Resource.GetAPIVersion(),
+				resource.Resource.GetKind())
+		}
+	}
+
+	if len(failedActions) > 0 {
+		// Use errors.Join to combine all collected errors into a single error
+		return processedResources, failedResources, skippedResources, errors.Join(failedActions...)
+	}
+
+	reportErrorCount(cleanerName, float64(numberOfErrors))
+
+	return processedResources, failedResources, skippedResources, nil
+}
+
+// scaleResource sets the replica count of resource, through its scale
+// subresource, to replicas. It returns the replica count resource had before.
+func scaleResource(ctx context.Context, c client.Client, resource *unstructured.Unstructured, replicas int32,
+	dryRun bool) (*int32, error) {
+
+	scale := &unstructured.Unstructured{}
+	scale.SetAPIVersion("autoscaling/v1")
+	scale.SetKind("Scale")
+	if err := c.SubResource(scaleSubresource).Get(ctx, resource, scale); err != nil {
+		return nil, err
+	}
+
+	previous, _, err := unstructured.NestedInt64(scale.Object, "spec", "replicas")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := unstructured.SetNestedField(scale.Object, int64(replicas), "spec", "replicas"); err != nil {
+		return nil, err
+	}
+
+	opts := []client.SubResourceUpdateOption{client.WithSubResourceBody(scale)}
+	if dryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+	if err := c.SubResource(scaleSubresource).Update(ctx, resource, opts...); err != nil {
+		return nil, err
+	}
+
+	return ptr.To(int32(previous)), nil
+}
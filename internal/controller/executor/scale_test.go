@@ -0,0 +1,174 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor_test
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+	"gianlucam76/k8s-cleaner/internal/controller/executor"
+)
+
+var _ = Describe("Scale", func() {
+	var ns *corev1.Namespace
+
+	BeforeEach(func() {
+		ns = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString()},
+		}
+		Expect(k8sClient.Create(context.TODO(), ns)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, ns)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), ns)).To(Succeed())
+	})
+
+	createDeployment := func(replicas int32) executor.ResourceResult {
+		depl := &appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name, Name: randomString()},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr.To(replicas),
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "app", Image: "nginx:1.25.3"}},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), depl)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, depl)).To(Succeed())
+
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(depl)
+		Expect(err).To(BeNil())
+		u := &unstructured.Unstructured{Object: content}
+		u.SetAPIVersion("apps/v1")
+		u.SetKind("Deployment")
+		return executor.ResourceResult{Resource: u}
+	}
+
+	getReplicas := func(resource executor.ResourceResult) int32 {
+		depl := &appsv1.Deployment{}
+		Expect(k8sClient.Get(context.TODO(), types.NamespacedName{
+			Namespace: resource.Resource.GetNamespace(), Name: resource.Resource.GetName()}, depl)).To(Succeed())
+		return *depl.Spec.Replicas
+	}
+
+	It("scales resources to the configured replica count, recording the previous one", func() {
+		resource := createDeployment(3)
+
+		processed, failed, skipped, err := executor.ScaleMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{resource}, &appsv1alpha1.ScaleOptions{Replicas: ptr.To(int32(0))},
+			false, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(failed).To(BeEmpty())
+		Expect(skipped).To(BeEmpty())
+		Expect(processed).To(HaveLen(1))
+		Expect(processed[0].PreviousReplicas).To(Equal(ptr.To(int32(3))))
+		Expect(processed[0].Replicas).To(Equal(ptr.To(int32(0))))
+
+		Expect(getReplicas(resource)).To(Equal(int32(0)))
+	})
+
+	It("prefers the replica count returned by evaluate", func() {
+		resource := createDeployment(1)
+		resource.Replicas = ptr.To(int32(2))
+
+		processed, _, _, err := executor.ScaleMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{resource}, &appsv1alpha1.ScaleOptions{Replicas: ptr.To(int32(0))},
+			false, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(processed).To(HaveLen(1))
+		Expect(processed[0].PreviousReplicas).To(Equal(ptr.To(int32(1))))
+
+		Expect(getReplicas(resource)).To(Equal(int32(2)))
+	})
+
+	It("leaves resources untouched in dry run", func() {
+		resource := createDeployment(2)
+
+		processed, _, _, err := executor.ScaleMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{resource}, &appsv1alpha1.ScaleOptions{Replicas: ptr.To(int32(0))},
+			true, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(processed).To(HaveLen(1))
+
+		Expect(getReplicas(resource)).To(Equal(int32(2)))
+	})
+
+	It("fails when no replica count is available", func() {
+		resource := createDeployment(2)
+
+		processed, failed, _, err := executor.ScaleMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{resource}, nil, false, logr.Discard())
+		Expect(err).ToNot(BeNil())
+		Expect(processed).To(BeEmpty())
+		Expect(failed).To(HaveLen(1))
+	})
+
+	It("rolls back a Scale action restoring the previous replica count", func() {
+		resource := createDeployment(3)
+
+		processed, _, _, err := executor.ScaleMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{resource}, &appsv1alpha1.ScaleOptions{Replicas: ptr.To(int32(0))},
+			false, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(processed).To(HaveLen(1))
+
+		cleanerName := randomString()
+		report := &appsv1alpha1.Report{
+			ObjectMeta: metav1.ObjectMeta{Name: cleanerName},
+			Spec: appsv1alpha1.ReportSpec{
+				Action: appsv1alpha1.ActionScale,
+				ResourceInfo: []appsv1alpha1.ResourceInfo{
+					{
+						Resource: corev1.ObjectReference{
+							Kind: "Deployment", APIVersion: "apps/v1",
+							Namespace: resource.Resource.GetNamespace(), Name: resource.Resource.GetName(),
+						},
+						PreviousReplicas: processed[0].PreviousReplicas,
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), report)).To(Succeed())
+
+		results, err := executor.Rollback(context.TODO(), k8sClient, cleanerName, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].Success).To(BeTrue())
+
+		Expect(getReplicas(resource)).To(Equal(int32(3)))
+
+		Expect(k8sClient.Delete(context.TODO(), report)).To(Succeed())
+	})
+})
@@ -0,0 +1,137 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+)
+
+const (
+	// defaultFieldManager is used for patch and server-side apply requests
+	// when TransformOptions.FieldManager is not set.
+	defaultFieldManager = "k8s-cleaner"
+)
+
+// validateTransformStatus returns an error unless the transform function
+// returned exactly one of resource, jsonPatch, mergePatch or
+// applyConfiguration.
+func validateTransformStatus(result *transformStatus) error {
+	set := 0
+	if result.Resource != nil {
+		set++
+	}
+	if result.JSONPatch != nil {
+		set++
+	}
+	if result.MergePatch != nil {
+		set++
+	}
+	if result.ApplyConfiguration != nil {
+		set++
+	}
+
+	if set != 1 {
+		return fmt.Errorf("transform must return exactly one of resource, jsonPatch, mergePatch " +
+			"or applyConfiguration")
+	}
+	return nil
+}
+
+// applyTransform sends the output of the transform function for resource to
+// the API server, using an Update, a JSON patch, a merge patch or a
+// server-side apply depending on which field the function returned.
+// It returns the object as persisted (or, in dryRun mode, as it would have
+// been persisted) by the API server.
+func applyTransform(ctx context.Context, resource *unstructured.Unstructured, result *transformStatus,
+	options *appsv1alpha1.TransformOptions, dryRun bool) (*unstructured.Unstructured, error) {
+
+	if err := validateTransformStatus(result); err != nil {
+		return nil, err
+	}
+
+	if result.Resource != nil {
+		if err := k8sClient.Update(ctx, result.Resource, updateOptions(dryRun)...); err != nil {
+			return nil, err
+		}
+		return result.Resource, nil
+	}
+
+	fieldManager := defaultFieldManager
+	force := false
+	if options != nil {
+		if options.FieldManager != "" {
+			fieldManager = options.FieldManager
+		}
+		force = options.Force
+	}
+
+	if result.ApplyConfiguration != nil {
+		obj := &unstructured.Unstructured{Object: result.ApplyConfiguration}
+		// A partial object only needs to carry the fields the script wants to
+		// own. Identity is taken from the matching resource.
+		obj.SetAPIVersion(resource.GetAPIVersion())
+		obj.SetKind(resource.GetKind())
+		obj.SetNamespace(resource.GetNamespace())
+		obj.SetName(resource.GetName())
+
+		applyOptions := []client.ApplyOption{client.FieldOwner(fieldManager)}
+		if force {
+			applyOptions = append(applyOptions, client.ForceOwnership)
+		}
+		if dryRun {
+			applyOptions = append(applyOptions, client.DryRunAll)
+		}
+		if err := k8sClient.Apply(ctx, client.ApplyConfigurationFromUnstructured(obj), applyOptions...); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	}
+
+	var patch client.Patch
+	if result.JSONPatch != nil {
+		data, err := json.Marshal(result.JSONPatch)
+		if err != nil {
+			return nil, err
+		}
+		patch = client.RawPatch(types.JSONPatchType, data)
+	} else {
+		data, err := json.Marshal(result.MergePatch)
+		if err != nil {
+			return nil, err
+		}
+		patch = client.RawPatch(types.MergePatchType, data)
+	}
+
+	patchOptions := []client.PatchOption{client.FieldOwner(fieldManager)}
+	if dryRun {
+		patchOptions = append(patchOptions, client.DryRunAll)
+	}
+
+	obj := resource.DeepCopy()
+	if err := k8sClient.Patch(ctx, obj, patch, patchOptions...); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor_test
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+	"gianlucam76/k8s-cleaner/internal/controller/executor"
+)
+
+const (
+	transformMergePatch = `function transform()
+  hs = {}
+  hs.mergePatch = {data = {k = "merge"}}
+  return hs
+end`
+
+	transformJSONPatch = `function transform()
+  hs = {}
+  hs.jsonPatch = {{op = "replace", path = "/data/k", value = "json"}}
+  return hs
+end`
+
+	transformApply = `function transform()
+  hs = {}
+  hs.applyConfiguration = {data = {k = "apply"}}
+  return hs
+end`
+
+	transformNoOutput = `function transform()
+  hs = {}
+  hs.message = "nothing"
+  return hs
+end`
+)
+
+var _ = Describe("Transform patch and server-side apply", func() {
+	var ns *corev1.Namespace
+
+	BeforeEach(func() {
+		ns = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString()},
+		}
+		Expect(k8sClient.Create(context.TODO(), ns)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, ns)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), ns)).To(Succeed())
+	})
+
+	createConfigMap := func() *unstructured.Unstructured {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name, Name: randomString()},
+			Data:       map[string]string{"k": "v"},
+		}
+		Expect(k8sClient.Create(context.TODO(), cm)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, cm)).To(Succeed())
+
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cm)
+		Expect(err).To(BeNil())
+		u := &unstructured.Unstructured{Object: content}
+		u.SetAPIVersion(apiVersionV1)
+		u.SetKind(kindConfigMap)
+		return u
+	}
+
+	getData := func(u *unstructured.Unstructured) map[string]string {
+		currentCm := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(context.TODO(),
+			types.NamespacedName{Namespace: u.GetNamespace(), Name: u.GetName()}, currentCm)).To(Succeed())
+		return currentCm.Data
+	}
+
+	DescribeTable("updateMatchingResources sends the request matching the transform output",
+		func(script, expected string) {
+			u := createConfigMap()
+
+			processed, _, _, err := executor.UpdateMatchingResources(context.TODO(), randomString(),
+				[]executor.ResourceResult{{Resource: u}}, script, nil, nil, false, logr.Discard())
+			Expect(err).To(BeNil())
+			Expect(processed).To(HaveLen(1))
+			Expect(getData(u)).To(HaveKeyWithValue("k", expected))
+		},
+		Entry("merge patch", transformMergePatch, "merge"),
+		Entry("JSON patch", transformJSONPatch, "json"),
+		Entry("server-side apply", transformApply, "apply"),
+	)
+
+	It("updateMatchingResources reports server-side apply conflicts per resource", func() {
+		u := createConfigMap()
+
+		// Another field manager takes ownership of data.k
+		cm := &unstructured.Unstructured{}
+		cm.SetAPIVersion(apiVersionV1)
+		cm.SetKind(kindConfigMap)
+		cm.SetNamespace(u.GetNamespace())
+		cm.SetName(u.GetName())
+		Expect(unstructured.SetNestedField(cm.Object, "other", "data", "k")).To(Succeed())
+		Expect(k8sClient.Apply(context.TODO(), client.ApplyConfigurationFromUnstructured(cm),
+			client.FieldOwner(randomString()), client.ForceOwnership)).To(Succeed())
+
+		processed, failed, _, err := executor.UpdateMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{{Resource: u}}, transformApply, nil, nil, false, logr.Discard())
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring(u.GetName()))
+		Expect(processed).To(BeEmpty())
+		Expect(failed).To(HaveLen(1))
+		Expect(failed[0].Message).ToNot(BeEmpty())
+		Expect(getData(u)).To(HaveKeyWithValue("k", "other"))
+
+		By("forcing ownership, the conflict is resolved in favor of the Cleaner")
+		options := &appsv1alpha1.TransformOptions{Force: true}
+		processed, _, _, err = executor.UpdateMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{{Resource: u}}, transformApply, options, nil, false, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(processed).To(HaveLen(1))
+		Expect(getData(u)).To(HaveKeyWithValue("k", "apply"))
+	})
+
+	It("updateMatchingResources fails when transform returns no output", func() {
+		u := createConfigMap()
+
+		processed, _, _, err := executor.UpdateMatchingResources(context.TODO(), randomString(),
+			[]executor.ResourceResult{{Resource: u}}, transformNoOutput, nil, nil, false, logr.Discard())
+		Expect(err).ToNot(BeNil())
+		Expect(processed).To(BeEmpty())
+	})
+
+	It("runTransform parses every supported transform output", func() {
+		u := newConfigMapResourceResult(randomString(), randomString(), nil).Resource
+
+		result, err := executor.RunTransform(context.TODO(), u, transformMergePatch, nil, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(result.MergePatch).ToNot(BeNil())
+
+		result, err = executor.RunTransform(context.TODO(), u, transformJSONPatch, nil, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(result.JSONPatch).To(HaveLen(1))
+
+		result, err = executor.RunTransform(context.TODO(), u, transformApply, nil, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(result.ApplyConfiguration).ToNot(BeNil())
+	})
+})
@@ -0,0 +1,271 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+)
+
+// rescuedMark is recorded for resources whose deletion mark was removed while
+// they were scheduled for deletion. They are not marked again as long as they
+// keep matching.
+const rescuedMark = "rescued"
+
+// deletionMarksSuffix identifies the ConfigMap recording, per resource key,
+// the deletionMark of each resource marked for deletion.
+const deletionMarksSuffix = "deletion-marks"
+
+// deletionMark is recorded, encoded in JSON, for each resource marked for
+// deletion.
+type deletionMark struct {
+	// DeleteAfter is the deadline set when the resource was marked, or
+	// rescuedMark.
+	DeleteAfter string `json:"deleteAfter"`
+	// Resource identifies the resource, so that its mark can be removed once
+	// it no longer matches.
+	Resource corev1.ObjectReference `json:"resource"`
+}
+
+// encodeDeletionMark returns the value recorded for resource.
+func encodeDeletionMark(resource *unstructured.Unstructured, deleteAfter string) string {
+	mark, _ := json.Marshal(deletionMark{
+		DeleteAfter: deleteAfter,
+		Resource: corev1.ObjectReference{
+			APIVersion: resource.GetAPIVersion(),
+			Kind:       resource.GetKind(),
+			Namespace:  resource.GetNamespace(),
+			Name:       resource.GetName(),
+			UID:        resource.GetUID(),
+		},
+	})
+	return string(mark)
+}
+
+// decodeDeletionMark parses a recorded value. Values recorded by earlier
+// releases are the bare deadline, or rescuedMark, and carry no Resource.
+func decodeDeletionMark(value string) deletionMark {
+	var mark deletionMark
+	if err := json.Unmarshal([]byte(value), &mark); err != nil {
+		return deletionMark{DeleteAfter: value}
+	}
+	return mark
+}
+
+// deleteResources takes a Delete Action of cleaner on resources, in two
+// phases if deleteOptions set TwoPhase.
+func deleteResources(ctx context.Context, cleaner *appsv1alpha1.Cleaner, deleteOptions *appsv1alpha1.DeleteOptions,
+	resources []ResourceResult, dryRun bool, logger logr.Logger,
+) (processedResources, failedResources, skippedResources []ResourceResult, err error) {
+
+	if deleteOptions == nil || deleteOptions.TwoPhase == nil {
+		return deleteMatchingResources(ctx, cleaner.Name, resources, deleteOptions, dryRun, logger)
+	}
+
+	// Protected resources are not even marked.
+	resources, skippedResources = skipProtectedResources(ctx, cleaner.Name, resources, logger)
+
+	toDelete, marked, pending, failedResources, err := scheduleDeletions(ctx, cleaner,
+		deleteOptions.TwoPhase.GracePeriod.Duration, resources, dryRun, time.Now(), logger)
+	skippedResources = append(skippedResources, pending...)
+	if err != nil {
+		return nil, failedResources, skippedResources, err
+	}
+
+	processedResources, deleteFailed, _, err := deleteMatchingResources(ctx, cleaner.Name, toDelete,
+		deleteOptions, dryRun, logger)
+	if !dryRun && len(processedResources) > 0 {
+		if forgetErr := forgetDeletionMarks(ctx, cleaner, processedResources); forgetErr != nil {
+			logger.Info(fmt.Sprintf("failed to forget deletion marks of deleted resources: %v", forgetErr))
+			if err == nil {
+				err = forgetErr
+			}
+		}
+	}
+	processedResources = append(processedResources, marked...)
+	failedResources = append(failedResources, deleteFailed...)
+	return processedResources, failedResources, skippedResources, err
+}
+
+// scheduleDeletions implements the first phase of a two-phase Delete. It
+// returns the resources whose grace period has elapsed and can be deleted,
+// the ones it just marked, the ones still waiting (or rescued) and the ones it
+// failed to mark. Which resources were marked is recorded in a ConfigMap, so
+// that a resource whose mark was removed is recognized as rescued.
+func scheduleDeletions(ctx context.Context, cleaner *appsv1alpha1.Cleaner, gracePeriod time.Duration,
+	resources []ResourceResult, dryRun bool, now time.Time, logger logr.Logger,
+) (toDelete, marked, pending, failed []ResourceResult, err error) {
+
+	marks, err := getCleanerConfigMapData(ctx, cleaner, deletionMarksSuffix)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	newMarks := make(map[string]string)
+	for i := range resources {
+		resource := resources[i].Resource
+		key := getResourceKey(resource)
+		recorded, isRecorded := marks[key]
+		deleteAfter, isMarked := resource.GetAnnotations()[appsv1alpha1.DeleteAfterAnnotation]
+
+		switch {
+		case isRecorded && (decodeDeletionMark(recorded).DeleteAfter == rescuedMark || !isMarked):
+			// Mark removed: the resource stays rescued for as long as it matches.
+			newMarks[key] = encodeDeletionMark(resource, rescuedMark)
+			pending = append(pending, ResourceResult{Resource: resource,
+				Message: fmt.Sprintf("rescued: %s annotation was removed", appsv1alpha1.DeleteAfterAnnotation)})
+		case isRecorded:
+			// The annotation, possibly edited to postpone the deletion, is authoritative.
+			newMarks[key] = encodeDeletionMark(resource, deleteAfter)
+			deadline, parseErr := time.Parse(time.RFC3339, deleteAfter)
+			switch {
+			case parseErr != nil:
+				pending = append(pending, ResourceResult{Resource: resource,
+					Message: fmt.Sprintf("invalid %s %q", appsv1alpha1.DeleteAfterAnnotation, deleteAfter)})
+			case now.Before(deadline):
+				pending = append(pending, ResourceResult{Resource: resource,
+					Message: fmt.Sprintf("scheduled for deletion at %s", deleteAfter)})
+			default:
+				// The mark is kept until the resource is deleted, so that a
+				// resource failing to be deleted is retried, not marked again.
+				toDelete = append(toDelete, resources[i])
+			}
+		default:
+			// Resources marked during an earlier matching streak get a new
+			// grace period, so owners are always notified before deletion.
+			deadline := now.Add(gracePeriod).UTC().Format(time.RFC3339)
+			waitForAction(ctx)
+			if err := markForDeletion(ctx, resource, cleaner.Name, deadline, dryRun); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				logger.Info(fmt.Sprintf("failed to mark %s %s/%s for deletion: %v", resource.GetKind(),
+					resource.GetNamespace(), resource.GetName(), err))
+				failed = append(failed, ResourceResult{Resource: resource, Message: err.Error()})
+				continue
+			}
+			newMarks[key] = encodeDeletionMark(resource, deadline)
+			marked = append(marked, ResourceResult{Resource: resource,
+				Message: fmt.Sprintf("scheduled for deletion at %s", deadline)})
+		}
+	}
+
+	if dryRun {
+		return toDelete, marked, pending, failed, nil
+	}
+
+	// Resources no longer matching are unmarked and forgotten: if they match
+	// again, they are marked again. A run on a changed resource only sees that
+	// resource, so it leaves the marks of the others alone. Resources failing
+	// to be unmarked stay recorded, so that the next run tries again.
+	var forgotten []string
+	if !isChangeRun(ctx) {
+		for key := range marks {
+			if _, ok := newMarks[key]; ok {
+				continue
+			}
+			mark := decodeDeletionMark(marks[key])
+			if mark.Resource.Name != "" {
+				waitForAction(ctx)
+				if err := unmarkForDeletion(ctx, &mark.Resource, cleaner.Name); err != nil {
+					logger.Info(fmt.Sprintf("failed to remove the deletion mark of %s %s/%s: %v",
+						mark.Resource.Kind, mark.Resource.Namespace, mark.Resource.Name, err))
+					continue
+				}
+			}
+			forgotten = append(forgotten, key)
+		}
+	}
+	if err := patchCleanerConfigMapData(ctx, cleaner, deletionMarksSuffix, newMarks, forgotten); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	return toDelete, marked, pending, failed, nil
+}
+
+// forgetDeletionMarks removes the recorded marks of deleted resources.
+func forgetDeletionMarks(ctx context.Context, cleaner *appsv1alpha1.Cleaner, deleted []ResourceResult) error {
+	keys := make([]string, len(deleted))
+	for i := range deleted {
+		keys[i] = getResourceKey(deleted[i].Resource)
+	}
+
+	return patchCleanerConfigMapData(ctx, cleaner, deletionMarksSuffix, nil, keys)
+}
+
+// markForDeletion annotates resource as scheduled for deletion by Cleaner
+// cleanerName after deadline.
+func markForDeletion(ctx context.Context, resource *unstructured.Unstructured, cleanerName, deadline string,
+	dryRun bool) error {
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				appsv1alpha1.DeleteAfterAnnotation: deadline,
+				appsv1alpha1.ScheduledByAnnotation: cleanerName,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return k8sClient.Patch(ctx, resource, client.RawPatch(types.MergePatchType, patch),
+		&client.PatchOptions{DryRun: dryRunOption(dryRun)})
+}
+
+// unmarkForDeletion removes the annotations markForDeletion set on the
+// resource ref refers to, unless it was recreated or another Cleaner has
+// marked it since.
+func unmarkForDeletion(ctx context.Context, ref *corev1.ObjectReference, cleanerName string) error {
+	resource := &unstructured.Unstructured{}
+	resource.SetAPIVersion(ref.APIVersion)
+	resource.SetKind(ref.Kind)
+	err := k8sClient.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, resource)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if resource.GetUID() != ref.UID ||
+		resource.GetAnnotations()[appsv1alpha1.ScheduledByAnnotation] != cleanerName {
+		return nil
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				appsv1alpha1.DeleteAfterAnnotation: nil,
+				appsv1alpha1.ScheduledByAnnotation: nil,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return client.IgnoreNotFound(k8sClient.Patch(ctx, resource, client.RawPatch(types.MergePatchType, patch)))
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor_test
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+	"gianlucam76/k8s-cleaner/internal/controller/executor"
+)
+
+var _ = Describe("Two-phase delete", func() {
+	var ns *corev1.Namespace
+	var cleaner *appsv1alpha1.Cleaner
+
+	BeforeEach(func() {
+		ns = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString()},
+		}
+		Expect(k8sClient.Create(context.TODO(), ns)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, ns)).To(Succeed())
+		// The ConfigMap recording marks is created in the controller namespace.
+		os.Setenv("NAMESPACE", ns.Name)
+
+		cleaner = &appsv1alpha1.Cleaner{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: randomString(),
+				UID:  types.UID(randomString()),
+			},
+			Spec: appsv1alpha1.CleanerSpec{
+				Action: appsv1alpha1.ActionDelete,
+				DeleteOptions: &appsv1alpha1.DeleteOptions{
+					TwoPhase: &appsv1alpha1.TwoPhaseDelete{GracePeriod: metav1.Duration{Duration: time.Hour}},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(executor.DeleteConfigMap(context.TODO(), cleaner)).To(Succeed())
+		Expect(k8sClient.Delete(context.TODO(), ns)).To(Succeed())
+	})
+
+	createConfigMap := func() *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name, Name: randomString()},
+		}
+		Expect(k8sClient.Create(context.TODO(), cm)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, cm)).To(Succeed())
+		return cm
+	}
+
+	getConfigMap := func(cm *corev1.ConfigMap) (*unstructured.Unstructured, error) {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion(apiVersionV1)
+		u.SetKind(kindConfigMap)
+		err := k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: cm.Namespace, Name: cm.Name}, u)
+		return u, err
+	}
+
+	run := func(cm *corev1.ConfigMap) (processed, skipped []executor.ResourceResult) {
+		u, err := getConfigMap(cm)
+		Expect(err).To(BeNil())
+		processed, failed, skipped, err := executor.DeleteResources(context.TODO(), cleaner,
+			cleaner.Spec.DeleteOptions, []executor.ResourceResult{{Resource: u}}, false, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(failed).To(BeEmpty())
+		return processed, skipped
+	}
+
+	It("marks resources first and deletes them once the grace period has elapsed", func() {
+		cm := createConfigMap()
+
+		processed, skipped := run(cm)
+		Expect(processed).To(HaveLen(1))
+		Expect(processed[0].Message).To(ContainSubstring("scheduled for deletion"))
+		Expect(skipped).To(BeEmpty())
+
+		u, err := getConfigMap(cm)
+		Expect(err).To(BeNil())
+		Expect(u.GetAnnotations()).To(HaveKey(appsv1alpha1.DeleteAfterAnnotation))
+		Expect(u.GetAnnotations()[appsv1alpha1.ScheduledByAnnotation]).To(Equal(cleaner.Name))
+
+		// Grace period has not elapsed yet
+		processed, skipped = run(cm)
+		Expect(processed).To(BeEmpty())
+		Expect(skipped).To(HaveLen(1))
+
+		// Move the deadline in the past
+		annotations := u.GetAnnotations()
+		annotations[appsv1alpha1.DeleteAfterAnnotation] = time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+		u.SetAnnotations(annotations)
+		Expect(k8sClient.Update(context.TODO(), u)).To(Succeed())
+
+		processed, _ = run(cm)
+		Expect(processed).To(HaveLen(1))
+		Eventually(func() bool {
+			_, err := getConfigMap(cm)
+			return apierrors.IsNotFound(err)
+		}, timeout, pollingInterval).Should(BeTrue())
+	})
+
+	It("keeps the mark of resources due for deletion until they are deleted", func() {
+		cm := createConfigMap()
+
+		processed, _ := run(cm)
+		Expect(processed).To(HaveLen(1))
+
+		u, err := getConfigMap(cm)
+		Expect(err).To(BeNil())
+		key := executor.GetResourceKey(u)
+
+		// Once the grace period has elapsed, the mark is still recorded when
+		// the resource is handed over to the delete phase.
+		toDelete, marked, pending, failed, err := executor.ScheduleDeletions(context.TODO(), cleaner, time.Hour,
+			[]executor.ResourceResult{{Resource: u}}, false, time.Now().Add(2*time.Hour), logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(toDelete).To(HaveLen(1))
+		Expect(marked).To(BeEmpty())
+		Expect(pending).To(BeEmpty())
+		Expect(failed).To(BeEmpty())
+
+		marks, err := executor.GetCleanerConfigMapData(context.TODO(), cleaner, executor.DeletionMarksSuffix)
+		Expect(err).To(BeNil())
+		Expect(marks).To(HaveKey(key))
+
+		// Move the deadline in the past
+		annotations := u.GetAnnotations()
+		annotations[appsv1alpha1.DeleteAfterAnnotation] = time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+		u.SetAnnotations(annotations)
+		Expect(k8sClient.Update(context.TODO(), u)).To(Succeed())
+
+		processed, _ = run(cm)
+		Expect(processed).To(HaveLen(1))
+
+		marks, err = executor.GetCleanerConfigMapData(context.TODO(), cleaner, executor.DeletionMarksSuffix)
+		Expect(err).To(BeNil())
+		Expect(marks).ToNot(HaveKey(key))
+	})
+
+	It("does not mark again resources whose mark was removed", func() {
+		cm := createConfigMap()
+
+		processed, _ := run(cm)
+		Expect(processed).To(HaveLen(1))
+
+		u, err := getConfigMap(cm)
+		Expect(err).To(BeNil())
+		u.SetAnnotations(nil)
+		Expect(k8sClient.Update(context.TODO(), u)).To(Succeed())
+
+		processed, skipped := run(cm)
+		Expect(processed).To(BeEmpty())
+		Expect(skipped).To(HaveLen(1))
+		Expect(skipped[0].Message).To(ContainSubstring("rescued"))
+
+		u, err = getConfigMap(cm)
+		Expect(err).To(BeNil())
+		Expect(u.GetAnnotations()).ToNot(HaveKey(appsv1alpha1.DeleteAfterAnnotation))
+	})
+
+	It("removes the mark of resources that no longer match", func() {
+		cm := createConfigMap()
+
+		processed, _ := run(cm)
+		Expect(processed).To(HaveLen(1))
+
+		u, err := getConfigMap(cm)
+		Expect(err).To(BeNil())
+		key := executor.GetResourceKey(u)
+
+		// A run the resource no longer matches.
+		_, _, _, _, err = executor.ScheduleDeletions(context.TODO(), cleaner, time.Hour, nil, false,
+			time.Now(), logr.Discard())
+		Expect(err).To(BeNil())
+
+		u, err = getConfigMap(cm)
+		Expect(err).To(BeNil())
+		Expect(u.GetAnnotations()).ToNot(HaveKey(appsv1alpha1.DeleteAfterAnnotation))
+		Expect(u.GetAnnotations()).ToNot(HaveKey(appsv1alpha1.ScheduledByAnnotation))
+
+		marks, err := executor.GetCleanerConfigMapData(context.TODO(), cleaner, executor.DeletionMarksSuffix)
+		Expect(err).To(BeNil())
+		Expect(marks).ToNot(HaveKey(key))
+
+		// Matching again, it gets a new grace period.
+		processed, _ = run(cm)
+		Expect(processed).To(HaveLen(1))
+		Expect(processed[0].Message).To(ContainSubstring("scheduled for deletion"))
+	})
+
+	It("leaves the mark another Cleaner set on resources that no longer match", func() {
+		cm := createConfigMap()
+
+		processed, _ := run(cm)
+		Expect(processed).To(HaveLen(1))
+
+		u, err := getConfigMap(cm)
+		Expect(err).To(BeNil())
+		annotations := u.GetAnnotations()
+		annotations[appsv1alpha1.ScheduledByAnnotation] = randomString()
+		u.SetAnnotations(annotations)
+		Expect(k8sClient.Update(context.TODO(), u)).To(Succeed())
+
+		_, _, _, _, err = executor.ScheduleDeletions(context.TODO(), cleaner, time.Hour, nil, false,
+			time.Now(), logr.Discard())
+		Expect(err).To(BeNil())
+
+		u, err = getConfigMap(cm)
+		Expect(err).To(BeNil())
+		Expect(u.GetAnnotations()).To(HaveKeyWithValue(appsv1alpha1.ScheduledByAnnotation,
+			annotations[appsv1alpha1.ScheduledByAnnotation]))
+		Expect(u.GetAnnotations()).To(HaveKey(appsv1alpha1.DeleteAfterAnnotation))
+	})
+})
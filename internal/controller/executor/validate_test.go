@@ -138,7 +138,7 @@ func verifyCleanerResourceSelector(dirName string) {
 		isMatch := false
 		for i := range cleaner.Spec.ResourcePolicySet.ResourceSelectors {
 			rs := &cleaner.Spec.ResourcePolicySet.ResourceSelectors[i]
-			tmpIsMatch, _, err := executor.IsMatch(matchingResource, rs.Evaluate, nil, nil, nil, nil, logger)
+			tmpIsMatch, _, err := executor.IsMatch(context.TODO(), matchingResource, rs.Evaluate, nil, nil, nil, nil, nil, nil, logger)
 			Expect(err).To(BeNil())
 			if tmpIsMatch {
 				isMatch = true
@@ -155,7 +155,7 @@ func verifyCleanerResourceSelector(dirName string) {
 		isMatch := false
 		for i := range cleaner.Spec.ResourcePolicySet.ResourceSelectors {
 			rs := &cleaner.Spec.ResourcePolicySet.ResourceSelectors[i]
-			tmpIsMatch, _, err := executor.IsMatch(nonMatchingResource, rs.Evaluate, nil, nil, nil, nil, logger)
+			tmpIsMatch, _, err := executor.IsMatch(context.TODO(), nonMatchingResource, rs.Evaluate, nil, nil, nil, nil, nil, nil, logger)
 			Expect(err).To(BeNil())
 			if tmpIsMatch {
 				isMatch = true
@@ -216,7 +216,7 @@ func verifyCleanerTransform(dirName string) {
 		for i := range cleaner.Spec.ResourcePolicySet.ResourceSelectors {
 			rs := &cleaner.Spec.ResourcePolicySet.ResourceSelectors[i]
 			var tmpIsMatch bool
-			tmpIsMatch, _, err = executor.IsMatch(matchingResource, rs.Evaluate, nil, nil, nil, nil, logger)
+			tmpIsMatch, _, err = executor.IsMatch(context.TODO(), matchingResource, rs.Evaluate, nil, nil, nil, nil, nil, nil, logger)
 			Expect(err).To(BeNil())
 			if tmpIsMatch {
 				isMatch = true
@@ -226,7 +226,7 @@ func verifyCleanerTransform(dirName string) {
 	}
 
 	var updatedResource *unstructured.Unstructured
-	updatedResource, err = executor.Transform(matchingResource, cleaner.Spec.Transform, logger)
+	updatedResource, err = executor.Transform(context.TODO(), matchingResource, cleaner.Spec.Transform, nil, logger)
 	Expect(err).To(BeNil())
 
 	expectedUpdatedResource := getResource(dirName, updatedFileName)
@@ -299,8 +299,8 @@ func verifyCleanerAggregatedSelection(dirName string) {
 	if resources == nil {
 		By(fmt.Sprintf("%s file not present", matchingFileName))
 	} else {
-		result, err = executor.AggregatedSelection(cleaner.Spec.ResourcePolicySet.AggregatedSelection,
-			resources, logger)
+		result, err = executor.AggregatedSelection(context.TODO(), cleaner.Spec.ResourcePolicySet.AggregatedSelection,
+			resources, nil, logger)
 		Expect(err).To(BeNil())
 		verifyMatchingResources(result, matchingResources)
 	}
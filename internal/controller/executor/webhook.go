@@ -0,0 +1,221 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+)
+
+const (
+	defaultWebhookTimeout    = 30 * time.Second
+	defaultWebhookMaxRetries = 3
+
+	// maxWebhookResponseBody caps how much of a response body is kept in
+	// the message of a failed request.
+	maxWebhookResponseBody = 512
+)
+
+// webhookRetryBackoff is the wait before the first retry of a failed webhook
+// request. It doubles at every further retry.
+var webhookRetryBackoff = time.Second
+
+// WebhookPayload is the JSON body POSTed to the webhook endpoint for each
+// matching resource.
+type WebhookPayload struct {
+	// Cleaner identifies the Cleaner instance that matched the resource.
+	Cleaner WebhookCleaner `json:"cleaner"`
+	// Message is the message returned by the evaluate function.
+	Message string `json:"message,omitempty"`
+	// Resource is the matching resource.
+	Resource *unstructured.Unstructured `json:"resource"`
+}
+
+// WebhookCleaner is the Cleaner metadata sent along each resource.
+type WebhookCleaner struct {
+	Name   string            `json:"name"`
+	UID    string            `json:"uid,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// webhookMatchingResources POSTs each resource to the endpoint configured in
+// webhookOptions. In dry run mode nothing is sent, as the endpoint has no way
+// to honor it.
+func webhookMatchingResources(ctx context.Context, cleaner *appsv1alpha1.Cleaner,
+	webhookOptions *appsv1alpha1.WebhookOptions, resources []ResourceResult, dryRun bool, logger logr.Logger,
+) (processedResources, failedResources, skippedResources []ResourceResult, err error) {
+
+	processedResources = make([]ResourceResult, 0)
+	var failedActions []error // Slice to store all errors
+
+	resources, skippedResources = skipProtectedResources(ctx, cleaner.Name, resources, logger)
+
+	if webhookOptions == nil {
+		return processedResources, nil, skippedResources,
+			errors.New("webhookOptions must be set when action is Webhook")
+	}
+
+	authHeader, err := authHeaderFromSecret(ctx, webhookOptions.SecretRef)
+	if err != nil {
+		return processedResources, nil, skippedResources, fmt.Errorf("reading webhook credentials: %w", err)
+	}
+
+	timeout := defaultWebhookTimeout
+	if webhookOptions.Timeout != nil {
+		timeout = webhookOptions.Timeout.Duration
+	}
+	maxRetries := defaultWebhookMaxRetries
+	if webhookOptions.MaxRetries != nil {
+		maxRetries = int(*webhookOptions.MaxRetries)
+	}
+	httpClient := &http.Client{Timeout: timeout}
+
+	if !dryRun {
+		reportUpdatedCount(cleaner.Name, float64(len(resources)))
+	}
+
+	numberOfErrors := 0
+	for i := range resources {
+		resource := resources[i]
+		l := logger.WithValues("resource", fmt.Sprintf("%s:%s/%s",
+			resource.Resource.GetKind(),
+			resource.Resource.GetNamespace(),
+			resource.Resource.GetName()))
+
+		if dryRun {
+			resource.Message = "dry run: webhook not called"
+			processedResources = append(processedResources, resource)
+			continue
+		}
+
+		waitForAction(ctx)
+		l.Info("calling webhook")
+		payload := &WebhookPayload{
+			Cleaner: WebhookCleaner{
+				Name:   cleaner.Name,
+				UID:    string(cleaner.UID),
+				Labels: cleaner.Labels,
+			},
+			Message:  resource.Message,
+			Resource: resource.Resource,
+		}
+		statusCode, callErr := callWebhook(ctx, httpClient, webhookOptions.URL, authHeader, payload,
+			maxRetries, l)
+		if callErr != nil {
+			numberOfErrors++
+			reportErrorEvent(cleaner.Name, resource.Resource.GetAPIVersion(),
+				resource.Resource.GetKind())
+			l.Info(fmt.Sprintf("failed to call webhook: %v", callErr))
+			failedActions = append(failedActions, fmt.Errorf("%s %s/%s: %w", resource.Resource.GetKind(),
+				resource.Resource.GetNamespace(), resource.Resource.GetName(), callErr))
+			failedResources = append(failedResources, ResourceResult{Resource: resource.Resource,
+				Message: callErr.Error()})
+			continue
+		}
+
+		if resource.Message == "" {
+			resource.Message = fmt.Sprintf("webhook returned HTTP %d", statusCode)
+		} else {
+			resource.Message = fmt.Sprintf("webhook returned HTTP %d: %s", statusCode, resource.Message)
+		}
+		processedResources = append(processedResources, resource)
+		reportUpdateEvent(cleaner.Name, resource.Resource.GetAPIVersion(),
+			resource.Resource.GetKind())
+	}
+
+	if len(failedActions) > 0 {
+		// Use errors.Join to combine all collected errors into a single error
+		return processedResources, failedResources, skippedResources, errors.Join(failedActions...)
+	}
+
+	reportErrorCount(cleaner.Name, float64(numberOfErrors))
+
+	return processedResources, failedResources, skippedResources, nil
+}
+
+// callWebhook POSTs payload to url, retrying up to maxRetries times, with
+// exponential backoff, on network errors, 429 and 5xx responses. It returns
+// the status code of the successful response.
+func callWebhook(ctx context.Context, httpClient *http.Client, url, authHeader string,
+	payload *WebhookPayload, maxRetries int, logger logr.Logger) (int, error) {
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, err
+	}
+
+	backoff := webhookRetryBackoff
+	for attempt := 0; ; attempt++ {
+		statusCode, retryable, err := postWebhook(ctx, httpClient, url, authHeader, body)
+		if err == nil {
+			return statusCode, nil
+		}
+		if !retryable || attempt >= maxRetries {
+			return 0, err
+		}
+
+		logger.Info(fmt.Sprintf("webhook call failed, retrying in %s: %v", backoff, err))
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// postWebhook sends a single request. It returns whether a failure is worth
+// retrying.
+func postWebhook(ctx context.Context, httpClient *http.Client, url, authHeader string,
+	body []byte) (statusCode int, retryable bool, err error) {
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, false, fmt.Errorf("building request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, true, fmt.Errorf("calling webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		// Drain the body so the connection can be reused.
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return resp.StatusCode, false, nil
+	}
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponseBody))
+	retryable = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+	return resp.StatusCode, retryable, fmt.Errorf("webhook returned HTTP %d: %s", resp.StatusCode, string(respBody))
+}
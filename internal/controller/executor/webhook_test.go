@@ -0,0 +1,206 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+	"gianlucam76/k8s-cleaner/internal/controller/executor"
+)
+
+var _ = Describe("Webhook", func() {
+	var previousBackoff time.Duration
+	var resource executor.ResourceResult
+
+	BeforeEach(func() {
+		previousBackoff = executor.SetWebhookRetryBackoff(time.Millisecond)
+
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("ConfigMap")
+		u.SetNamespace(randomString())
+		u.SetName(randomString())
+		resource = executor.ResourceResult{Resource: u, Message: "not used by any pod"}
+	})
+
+	AfterEach(func() {
+		executor.SetWebhookRetryBackoff(previousBackoff)
+	})
+
+	newCleaner := func(webhookOptions *appsv1alpha1.WebhookOptions) *appsv1alpha1.Cleaner {
+		return &appsv1alpha1.Cleaner{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   randomString(),
+				UID:    types.UID(randomString()),
+				Labels: map[string]string{"team": "platform"},
+			},
+			Spec: appsv1alpha1.CleanerSpec{
+				Action:         appsv1alpha1.ActionWebhook,
+				WebhookOptions: webhookOptions,
+			},
+		}
+	}
+
+	It("POSTs each resource with the evaluate message and the Cleaner metadata", func() {
+		ns := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString()},
+		}
+		Expect(k8sClient.Create(context.TODO(), ns)).To(Succeed())
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name, Name: randomString()},
+			Data:       map[string][]byte{"token": []byte("my-token")},
+		}
+		Expect(k8sClient.Create(context.TODO(), secret)).To(Succeed())
+		Expect(waitForObject(context.TODO(), k8sClient, secret)).To(Succeed())
+
+		var received executor.WebhookPayload
+		var authorization, method string
+		var decodeErr error
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+			method = r.Method
+			decodeErr = json.NewDecoder(r.Body).Decode(&received)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		cleaner := newCleaner(&appsv1alpha1.WebhookOptions{
+			URL:       server.URL,
+			SecretRef: &corev1.SecretReference{Namespace: secret.Namespace, Name: secret.Name},
+		})
+
+		processed, failed, _, err := executor.WebhookMatchingResources(context.TODO(), cleaner, cleaner.Spec.WebhookOptions,
+			[]executor.ResourceResult{resource}, false, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(failed).To(BeEmpty())
+		Expect(processed).To(HaveLen(1))
+		Expect(processed[0].Message).To(Equal("webhook returned HTTP 202: not used by any pod"))
+
+		Expect(method).To(Equal(http.MethodPost))
+		Expect(decodeErr).To(BeNil())
+		Expect(authorization).To(Equal("Bearer my-token"))
+		Expect(received.Cleaner.Name).To(Equal(cleaner.Name))
+		Expect(received.Cleaner.UID).To(Equal(string(cleaner.UID)))
+		Expect(received.Cleaner.Labels).To(Equal(cleaner.Labels))
+		Expect(received.Message).To(Equal(resource.Message))
+		Expect(received.Resource.GetName()).To(Equal(resource.Resource.GetName()))
+		Expect(received.Resource.GetKind()).To(Equal("ConfigMap"))
+
+		Expect(k8sClient.Delete(context.TODO(), ns)).To(Succeed())
+	})
+
+	It("retries failed requests", func() {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		processed, failed, _, err := executor.WebhookMatchingResources(context.TODO(),
+			newCleaner(nil), &appsv1alpha1.WebhookOptions{URL: server.URL},
+			[]executor.ResourceResult{resource}, false, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(failed).To(BeEmpty())
+		Expect(processed).To(HaveLen(1))
+		Expect(calls.Load()).To(Equal(int32(3)))
+	})
+
+	It("gives up after maxRetries", func() {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		processed, failed, _, err := executor.WebhookMatchingResources(context.TODO(),
+			newCleaner(nil), &appsv1alpha1.WebhookOptions{URL: server.URL, MaxRetries: ptr.To(int32(1))},
+			[]executor.ResourceResult{resource}, false, logr.Discard())
+		Expect(err).ToNot(BeNil())
+		Expect(processed).To(BeEmpty())
+		Expect(failed).To(HaveLen(1))
+		Expect(failed[0].Message).To(ContainSubstring("HTTP 429"))
+		Expect(calls.Load()).To(Equal(int32(2)))
+	})
+
+	It("does not retry client errors", func() {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		_, failed, _, err := executor.WebhookMatchingResources(context.TODO(),
+			newCleaner(nil), &appsv1alpha1.WebhookOptions{URL: server.URL},
+			[]executor.ResourceResult{resource}, false, logr.Discard())
+		Expect(err).ToNot(BeNil())
+		Expect(failed).To(HaveLen(1))
+		Expect(calls.Load()).To(Equal(int32(1)))
+	})
+
+	It("times out slow endpoints", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		_, failed, _, err := executor.WebhookMatchingResources(context.TODO(),
+			newCleaner(nil), &appsv1alpha1.WebhookOptions{URL: server.URL, MaxRetries: ptr.To(int32(0)),
+				Timeout: &metav1.Duration{Duration: 50 * time.Millisecond}},
+			[]executor.ResourceResult{resource}, false, logr.Discard())
+		Expect(err).ToNot(BeNil())
+		Expect(failed).To(HaveLen(1))
+	})
+
+	It("does not call the endpoint in dry run", func() {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		processed, _, _, err := executor.WebhookMatchingResources(context.TODO(),
+			newCleaner(nil), &appsv1alpha1.WebhookOptions{URL: server.URL},
+			[]executor.ResourceResult{resource}, true, logr.Discard())
+		Expect(err).To(BeNil())
+		Expect(processed).To(HaveLen(1))
+		Expect(calls.Load()).To(BeZero())
+	})
+})
@@ -21,6 +21,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -30,15 +32,14 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
-	"k8s.io/client-go/restmapper"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
@@ -73,11 +74,31 @@ type ResourceResult struct {
 	// Message is an optional field.
 	// +optional
 	Message string `json:"message,omitempty"`
+
+	// Diff is the JSON merge patch a dry-run Transform would have applied.
+	// +optional
+	Diff string `json:"diff,omitempty"`
+
+	// Replicas, set by the evaluate function, is the replica count a Scale
+	// action scales the resource to.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// PreviousReplicas is the replica count of the resource before a Scale
+	// action changed it.
+	// +optional
+	PreviousReplicas *int32 `json:"previousReplicas,omitempty"`
+
+	// Removed is set when the Action removed the resource, for instance
+	// deleted it, so that later steps of a pipeline do not act on it.
+	// +optional
+	Removed bool `json:"removed,omitempty"`
 }
 
 type responseParams struct {
 	cleanerName string
 	err         error
+	stats       *runStats
 }
 
 var (
@@ -86,24 +107,33 @@ var (
 	scheme    *runtime.Scheme
 	// clientset is a typed Kubernetes clientset, needed alongside k8sClient
 	// (controller-runtime's client.Client) because fetching container logs
-	// hits the pods/log subresource, which client.Client does not expose.
-	clientset *kubernetes.Clientset
+	// hits the pods/log subresource, which client.Client does not expose. It
+	// also lists the Pods of a Node being drained, uncached.
+	clientset kubernetes.Interface
 )
 
 const (
 	luaTableError = "lua script output is not a lua table"
 	luaBoolError  = "lua script output is not a lua bool"
 	apiVersionV1  = "v1"
+
+	// defaultPageSize is the number of resources requested per List call when
+	// a ResourceSelector does not set PageSize.
+	defaultPageSize = int64(500)
 )
 
 type evaluateStatus struct {
 	Matching bool   `json:"matching"`
 	Message  string `json:"message"`
+	Replicas *int32 `json:"replicas,omitempty"`
 }
 
 type transformStatus struct {
-	Resource *unstructured.Unstructured `json:"resource"`
-	Message  string                     `json:"message"`
+	Resource           *unstructured.Unstructured `json:"resource"`
+	JSONPatch          []interface{}              `json:"jsonPatch"`
+	MergePatch         map[string]interface{}     `json:"mergePatch"`
+	ApplyConfiguration map[string]interface{}     `json:"applyConfiguration"`
+	Message            string                     `json:"message"`
 }
 
 type aggregatedStatus struct {
@@ -125,8 +155,8 @@ func processRequests(ctx context.Context, i int, logger logr.Logger) {
 			l := logger.WithValues("cleaner", cleanerName)
 			// Get error only from getIsCleanupFromKey as same key is always used
 			l.Info(fmt.Sprintf("worker: %d processing request", id))
-			err := processCleanerInstance(ctx, *cleanerName, l)
-			storeResult(*cleanerName, err, l)
+			stats, err := processCleanerInstance(ctx, *cleanerName, l)
+			storeResult(*cleanerName, stats, err, l)
 			l.Info(fmt.Sprintf("worker: %d request processed", id))
 		}
 		cleanerName = nil
@@ -160,95 +190,146 @@ func processRequests(ctx context.Context, i int, logger logr.Logger) {
 	}
 }
 
-func processCleanerInstance(ctx context.Context, cleanerName string, logger logr.Logger) error {
+// processCleanerInstance runs the Cleaner once. The returned runStats is nil
+// only when the run did not start (Cleaner not found or invalid).
+func processCleanerInstance(ctx context.Context, cleanerName string, logger logr.Logger,
+) (stats *runStats, err error) {
+
+	startTime := time.Now()
+
 	cleaner, err := getCleanerInstance(ctx, cleanerName)
 	if err != nil {
 		logger.Info(fmt.Sprintf("failed to get cleaner instance: %v", err))
-		return err
+		return nil, err
 	}
 	if cleaner == nil {
 		logger.V(logs.LogDebug).Info("cleaner instance not found")
-		return nil
+		return nil, nil
 	}
 
-	if err := validateRollbackConfig(cleaner); err != nil {
+	if err := ValidateRollbackConfig(cleaner); err != nil {
 		logger.Info(fmt.Sprintf("invalid rollback configuration, skipping run: %v", err))
-		return err
+		return nil, err
 	}
 
+	if err := luaScripts.load(cleaner); err != nil {
+		logger.Info(fmt.Sprintf("failed to compile Lua scripts, skipping run: %v", err))
+		return nil, err
+	}
+
+	stats = &runStats{startTime: startTime}
+	defer func() {
+		stats.endTime = time.Now()
+		// A failure to record history must not mask the outcome of the run.
+		_ = recordExecution(ctx, cleaner, stats, err, logger)
+	}()
+
+	trackCachedResources(ctx, cleaner, logger)
+
 	resources := make([]ResourceResult, 0)
 	totalScanned := 0
+	lookups := newLookupCache(k8sClient)
 	for i := range cleaner.Spec.ResourcePolicySet.ResourceSelectors {
 		selector := &cleaner.Spec.ResourcePolicySet.ResourceSelectors[i]
 		var tmpResources []ResourceResult
 		var scanned int
-		tmpResources, scanned, err = getMatchingResources(ctx, selector, logger)
+		tmpResources, scanned, err = getMatchingResources(ctx, selector, cleaner.Spec.LuaLimits,
+			cleaner.Spec.EvaluationConcurrency, lookups, logger)
 		if err != nil {
 			logger.Info(fmt.Sprintf("failed to fetch resource (gvk: %s): %v",
 				fmt.Sprintf("%s:%s:%s", selector.Group, selector.Version, selector.Kind), err))
-			return err
+			return stats, err
 		}
 		resources = append(resources, tmpResources...)
 		totalScanned += scanned
+		stats.scanned = totalScanned
 	}
 
 	if cleaner.Spec.ResourcePolicySet.AggregatedSelection != "" {
-		resources, err = aggregatedSelection(cleaner.Spec.ResourcePolicySet.AggregatedSelection, resources,
-			logger)
+		resources, err = aggregatedSelection(ctx, cleaner.Spec.ResourcePolicySet.AggregatedSelection, resources,
+			cleaner.Spec.LuaLimits, logger)
 		if err != nil {
 			logger.Info(fmt.Sprintf("failed to filter aggregated resources: %v", err))
-			return err
+			return stats, err
 		}
 	}
 
 	throttledResources, err := getThrottledResources(ctx, cleaner)
 	if err != nil {
 		logger.Info(fmt.Sprintf("failed to get throttled resources: %v", err))
-		return err
+		return stats, err
 	}
 
 	filteredResources := filterResourcesByThreshold(resources, throttledResources, cleaner.Spec.OccurrenceThreshold-1)
+	stats.matched = len(filteredResources)
+
+	// Update the ConfigMap registry with CURRENT unhealthy matches
+	// This increments counts for existing ones and adds new ones.
+	// It also "heals" (removes) resources not present in 'resources'.
+	if updateErr := updateRegistry(ctx, cleaner, resources, throttledResources); updateErr != nil {
+		logger.Info(fmt.Sprintf("failed to update registry: %v", updateErr))
+		// We log but don't necessarily return here so notifications still go out
+	}
+
+	return stats, actOnMatches(ctx, cleaner, filteredResources, totalScanned, stats, logger)
+}
+
+// actOnMatches takes the Action of cleaner on matches, then sends Notifications
+// and stores resources, recording the outcome in stats. It is shared by
+// scheduled runs and runs triggered by a change. totalScanned is the number of
+// resources matches were selected from, 0 if unknown, and is used by the
+// MaxPercentage of BlastRadiusLimit.
+func actOnMatches(ctx context.Context, cleaner *appsv1alpha1.Cleaner, matches []ResourceResult,
+	totalScanned int, stats *runStats, logger logr.Logger) (err error) {
 
 	var processedResources []ResourceResult
-	if cleaner.Spec.Action != appsv1alpha1.ActionScan {
-		err = checkBlastRadiusLimit(cleaner.Spec.BlastRadiusLimit, len(filteredResources), totalScanned)
+	if takesAction(cleaner) {
+		err = checkBlastRadiusLimit(cleaner.Spec.BlastRadiusLimit, len(matches), totalScanned)
 	}
 	if err != nil {
 		logger.Info(fmt.Sprintf("blast radius limit exceeded, skipping action: %v", err))
-		processedResources = filteredResources
+		stats.blastRadiusExceeded = true
+		processedResources = matches
 	} else {
+		dryRun := isDryRun(cleaner)
+
+		if takesAction(cleaner) {
+			matches, stats.deferred, err = deferResources(ctx, cleaner, matches, dryRun, logger)
+			if err != nil {
+				logger.Info(fmt.Sprintf("failed to defer resources beyond maxActionsPerRun: %v", err))
+				return err
+			}
+		}
+
 		// Rollback data must be durably persisted before any resource is deleted or
 		// transformed. Otherwise a crash between the two steps would leave resources
 		// mutated with no way to revert them.
-		if snapshotErr := persistRollbackSnapshot(ctx, cleaner, filteredResources, logger); snapshotErr != nil {
+		if snapshotErr := persistRollbackSnapshot(ctx, cleaner, matches, logger); snapshotErr != nil {
 			logger.Info(fmt.Sprintf("failed to persist rollback snapshot, skipping action: %v", snapshotErr))
 			return snapshotErr
 		}
 
-		switch cleaner.Spec.Action {
-		case appsv1alpha1.ActionDelete:
-			processedResources, err = deleteMatchingResources(ctx, cleanerName, filteredResources,
-				cleaner.Spec.DeleteOptions, logger)
-		case appsv1alpha1.ActionTransform:
-			processedResources, err = updateMatchingResources(ctx, cleanerName, filteredResources,
-				cleaner.Spec.Transform, logger)
-		case appsv1alpha1.ActionScan:
-			printMatchingResources(cleanerName, filteredResources, logger)
-			processedResources = filteredResources
+		if dryRun {
+			logger.Info("dryRun is set: delete/update requests will not be persisted")
 		}
-	}
 
-	// Update the ConfigMap registry with CURRENT unhealthy matches
-	// This increments counts for existing ones and adds new ones.
-	// It also "heals" (removes) resources not present in 'resources'.
-	if updateErr := updateRegistry(ctx, cleaner, resources, throttledResources); updateErr != nil {
-		logger.Info(fmt.Sprintf("failed to update registry: %v", updateErr))
-		// We log but don't necessarily return here so notifications still go out
+		actionCtx := withActionPacer(ctx, cleaner.Spec.Execution)
+		if len(cleaner.Spec.Actions) > 0 {
+			processedResources, stats.failed, stats.skipped, stats.steps, err = runActionSteps(actionCtx, cleaner,
+				matches, dryRun, logger)
+		} else {
+			processedResources, stats.failed, stats.skipped, err = takeAction(actionCtx, cleaner,
+				cleanerActionStep(cleaner), matches, dryRun, logger)
+		}
+		stats.processed = processedResources
 	}
 
 	// Send notification irrespective of err
-	sendErr := sendNotifications(ctx, processedResources, cleaner, logger)
+	skippedResources := append(append([]ResourceResult{}, stats.skipped...), stats.deferred...)
+	sendErr := sendNotifications(ctx, processedResources, skippedResources, stats.failed, stats.steps,
+		cleaner, logger)
 	if sendErr != nil {
+		stats.notificationErr = sendErr
 		return sendErr
 	}
 
@@ -263,96 +344,205 @@ func processCleanerInstance(ctx context.Context, cleanerName string, logger logr
 
 // getMatchingResources returns the resources selected by sr along with the total
 // number of resources it considered (before label/Lua filtering narrows them down).
-// The total is used for BlastRadiusLimit's MaxPercentage check.
-func getMatchingResources(ctx context.Context, sr *appsv1alpha1.ResourceSelector, logger logr.Logger,
+// The total is used for BlastRadiusLimit's MaxPercentage check. Up to concurrency
+// resources (the controller-wide default if nil) are evaluated at once; matches
+// are returned in the order resources are listed.
+func getMatchingResources(ctx context.Context, sr *appsv1alpha1.ResourceSelector,
+	luaLimits *appsv1alpha1.LuaLimits, concurrency *int32, lookups *lookupCache, logger logr.Logger,
 ) ([]ResourceResult, int, error) {
 
-	resources, err := fetchResources(ctx, sr, logger)
-	if err != nil {
-		logger.Info(fmt.Sprintf("failed to fetch resources: %v", err))
-		return nil, 0, err
-	}
-
-	if resources == nil {
-		return nil, 0, nil
-	}
-
-	metricsData, err := fetchMetrics(ctx, sr, logger)
-	if err != nil {
-		logger.Info(fmt.Sprintf("failed to fetch metrics: %v", err))
-		return nil, 0, err
+	if lookups == nil {
+		lookups = newLookupCache(k8sClient)
 	}
 
 	// LogSource only makes sense for Pods; decided once here (rather than per
 	// resource in the loop below) so a misconfigured non-Pod ResourceSelector
 	// logs a single warning instead of one per matched resource.
-	targetsPods := sr.Group == "" && sr.Version == apiVersionV1 && sr.Kind == "Pod"
+	targetsPods := selectsPods(sr)
 	if sr.LogSource != nil && !targetsPods {
 		logger.Info("logSource is set but this ResourceSelector does not target Pods; ignoring")
 	}
 
-	results := make([]ResourceResult, 0)
-	for i := range resources {
-		resource := &resources[i]
+	// Metrics are only fetched once there is at least one resource to evaluate.
+	var metricsData map[string]float64
+	metricsFetched := false
+
+	// Lua states are reused across the evaluations of this selector.
+	pool := newLuaSandboxPool(luaLimits)
+	defer pool.close()
+
+	type candidate struct {
+		index    int
+		resource *unstructured.Unstructured
+	}
+	type match struct {
+		index  int
+		result ResourceResult
+	}
+
+	// Resources are listed, and filtered, sequentially. Candidates are then
+	// evaluated by a bounded set of workers; only matches are kept.
+	evalCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		mu         sync.Mutex
+		matches    []match
+		evalErr    error
+		errIndex   int
+		wg         sync.WaitGroup
+		candidates = make(chan candidate)
+	)
+	failed := func() error {
+		mu.Lock()
+		defer mu.Unlock()
+		return evalErr
+	}
+
+	for range resolveEvaluationConcurrency(concurrency) {
+		wg.Go(func() {
+			for c := range candidates {
+				if evalCtx.Err() != nil {
+					continue
+				}
+				l := logger.WithValues("resource", fmt.Sprintf("%s:%s/%s",
+					c.resource.GetKind(), c.resource.GetNamespace(), c.resource.GetName()))
+				l.V(logs.LogDebug).Info("considering resource for deletion")
+
+				result, err := evaluateResource(evalCtx, sr, c.resource, luaLimits, lookups, metricsData,
+					targetsPods, pool, l)
+
+				mu.Lock()
+				switch {
+				case err != nil:
+					// The error of the first listed resource wins, so that it
+					// does not depend on scheduling.
+					if evalErr == nil || c.index < errIndex {
+						evalErr, errIndex = err, c.index
+					}
+					cancel()
+				case result.Matching:
+					l.Info(fmt.Sprintf("getMatchingResources: found a match %q", result.Message))
+					matches = append(matches, match{index: c.index, result: ResourceResult{
+						Resource: c.resource,
+						Message:  result.Message,
+						Replicas: result.Replicas,
+					}})
+				}
+				mu.Unlock()
+			}
+		})
+	}
+
+	scanned := 0
+	candidateCount := 0
+	err := visitResources(ctx, sr, logger, func(resource *unstructured.Unstructured) error {
+		if err := failed(); err != nil {
+			return err
+		}
+
+		scanned++
 		if sr.ExcludeDeleted && !resource.GetDeletionTimestamp().IsZero() {
-			continue
+			return nil
 		}
-		l := logger.WithValues("resource", fmt.Sprintf("%s:%s/%s",
-			resource.GetKind(), resource.GetNamespace(), resource.GetName()))
-		l.V(logs.LogDebug).Info("considering resource for deletion")
-
-		// events and logs are best-effort: a fetch failure for one candidate
-		// resource should not abort evaluation of every other candidate in
-		// this ResourceSelector, so failures are logged and treated as "no
-		// data available" rather than propagated.
-		var resourceEvents []corev1.Event
-		if sr.IncludeEvents {
-			resourceEvents, err = fetchEvents(ctx, resource)
-			if err != nil {
-				l.Info(fmt.Sprintf("failed to fetch events, evaluating without them: %v", err))
-				resourceEvents = nil
-			}
+
+		// Declarative filters are cheap compared to creating a Lua state.
+		passes, err := passesFilters(ctx, sr, resource, lookups)
+		if err != nil {
+			return err
+		}
+		if !passes {
+			return nil
 		}
 
-		var currentLogs, previousLogs *containerLogTails
-		if sr.LogSource != nil && targetsPods {
-			pod := &corev1.Pod{}
-			if convErr := runtime.DefaultUnstructuredConverter.FromUnstructured(
-				resource.UnstructuredContent(), pod); convErr != nil {
-				l.Info(fmt.Sprintf("failed to convert resource to Pod, evaluating without logs: %v", convErr))
-			} else {
-				currentLogs, previousLogs, err = fetchPodLogs(ctx, pod, sr.LogSource, l)
-				if err != nil {
-					l.Info(fmt.Sprintf("failed to fetch logs, evaluating without them: %v", err))
-					currentLogs, previousLogs = nil, nil
-				}
+		if !metricsFetched {
+			metricsData, err = fetchMetrics(ctx, sr, logger)
+			if err != nil {
+				logger.Info(fmt.Sprintf("failed to fetch metrics: %v", err))
+				return err
 			}
+			metricsFetched = true
 		}
 
-		isMatch, message, err := isMatch(resource, sr.Evaluate, metricsData, resourceEvents, currentLogs, previousLogs, l)
+		candidates <- candidate{index: candidateCount, resource: resource}
+		candidateCount++
+		return nil
+	})
+	close(candidates)
+	wg.Wait()
+	if err == nil {
+		err = evalErr
+	}
+	if err != nil {
+		logger.Info(fmt.Sprintf("failed to fetch resources: %v", err))
+		return nil, 0, err
+	}
+
+	slices.SortFunc(matches, func(a, b match) int { return a.index - b.index })
+	results := make([]ResourceResult, len(matches))
+	for i := range matches {
+		results[i] = matches[i].result
+	}
+
+	return results, scanned, nil
+}
+
+// selectsPods returns true if sr selects Pods.
+func selectsPods(sr *appsv1alpha1.ResourceSelector) bool {
+	return sr.Group == "" && sr.Version == apiVersionV1 && sr.Kind == "Pod"
+}
+
+// evaluateResource fetches the events and logs sr asks for and runs the
+// Evaluate function on resource.
+func evaluateResource(ctx context.Context, sr *appsv1alpha1.ResourceSelector, resource *unstructured.Unstructured,
+	luaLimits *appsv1alpha1.LuaLimits, lookups *lookupCache, metricsData map[string]float64, targetsPods bool,
+	pool *luaSandboxPool, logger logr.Logger) (*evaluateStatus, error) {
+
+	var err error
+	// events and logs are best-effort: a fetch failure for one candidate
+	// resource should not abort evaluation of every other candidate in
+	// this ResourceSelector, so failures are logged and treated as "no
+	// data available" rather than propagated.
+	var resourceEvents []corev1.Event
+	if sr.IncludeEvents {
+		resourceEvents, err = fetchEvents(ctx, resource)
 		if err != nil {
-			return nil, 0, err
+			logger.Info(fmt.Sprintf("failed to fetch events, evaluating without them: %v", err))
+			resourceEvents = nil
 		}
-		if isMatch {
-			l.Info(fmt.Sprintf("getMatchingResources: found a match %q", message))
-			resourceInfo := ResourceResult{
-				Resource: resource,
-				Message:  message,
+	}
+
+	var currentLogs, previousLogs *containerLogTails
+	if sr.LogSource != nil && targetsPods {
+		pod := &corev1.Pod{}
+		if convErr := runtime.DefaultUnstructuredConverter.FromUnstructured(
+			resource.UnstructuredContent(), pod); convErr != nil {
+			logger.Info(fmt.Sprintf("failed to convert resource to Pod, evaluating without logs: %v", convErr))
+		} else {
+			currentLogs, previousLogs, err = fetchPodLogs(ctx, pod, sr.LogSource, logger)
+			if err != nil {
+				logger.Info(fmt.Sprintf("failed to fetch logs, evaluating without them: %v", err))
+				currentLogs, previousLogs = nil, nil
 			}
-			results = append(results, resourceInfo)
 		}
 	}
 
-	return results, len(resources), nil
+	return runEvaluateWithGlobals(ctx, resource, sr.Evaluate, luaLimits, lookups, pool, func(l *lua.LState) {
+		setEvaluateGlobals(l, metricsData, resourceEvents, currentLogs, previousLogs)
+	}, logger)
 }
 
 func deleteMatchingResources(ctx context.Context, cleanerName string, resources []ResourceResult,
-	deleteOptions *appsv1alpha1.DeleteOptions, logger logr.Logger) ([]ResourceResult, error) {
+	deleteOptions *appsv1alpha1.DeleteOptions, dryRun bool, logger logr.Logger,
+) (processedResources, failedResources, skippedResources []ResourceResult, err error) {
 
-	processedResources := make([]ResourceResult, 0)
+	processedResources = make([]ResourceResult, 0)
 	var failedActions []error // Slice to store all errors
 
-	reportDeletedCount(cleanerName, float64(len(resources)))
+	resources, skippedResources = skipProtectedResources(ctx, cleanerName, resources, logger)
+
+	if !dryRun {
+		reportDeletedCount(cleanerName, float64(len(resources)))
+	}
 
 	numberOfErrors := 0
 	for i := range resources {
@@ -363,12 +553,13 @@ func deleteMatchingResources(ctx context.Context, cleanerName string, resources
 			resource.Resource.GetName()))
 		l.Info("deleting resource")
 
-		options := &client.DeleteOptions{}
+		options := &client.DeleteOptions{DryRun: dryRunOption(dryRun)}
 		if deleteOptions != nil {
 			options.GracePeriodSeconds = deleteOptions.GracePeriodSeconds
 			options.PropagationPolicy = deleteOptions.PropagationPolicy
 		}
 
+		waitForAction(ctx)
 		if err := k8sClient.Delete(ctx, resource.Resource, options); err != nil {
 			if apierrors.IsNotFound(err) {
 				// Cleaner was about to delete a resource, but the resource is gone
@@ -380,30 +571,40 @@ func deleteMatchingResources(ctx context.Context, cleanerName string, resources
 				resource.Resource.GetKind())
 			l.Info(fmt.Sprintf("failed to delete resource: %v", err))
 			failedActions = append(failedActions, err)
+			failedResources = append(failedResources, ResourceResult{Resource: resource.Resource, Message: err.Error()})
 		} else {
+			resource.Removed = true
 			processedResources = append(processedResources, resource)
-			reportDeletionEvent(cleanerName, resource.Resource.GetAPIVersion(),
-				resource.Resource.GetKind())
+			if !dryRun {
+				reportDeletionEvent(cleanerName, resource.Resource.GetAPIVersion(),
+					resource.Resource.GetKind())
+			}
 		}
 	}
 
 	if len(failedActions) > 0 {
 		// Use errors.Join to combine all collected errors into a single error
-		return processedResources, errors.Join(failedActions...)
+		return processedResources, failedResources, skippedResources, errors.Join(failedActions...)
 	}
 
 	reportErrorCount(cleanerName, float64(numberOfErrors))
 
-	return processedResources, nil
+	return processedResources, failedResources, skippedResources, nil
 }
 
 func updateMatchingResources(ctx context.Context, cleanerName string, resources []ResourceResult,
-	transformFunction string, logger logr.Logger) ([]ResourceResult, error) {
+	transformFunction string, transformOptions *appsv1alpha1.TransformOptions,
+	luaLimits *appsv1alpha1.LuaLimits, dryRun bool, logger logr.Logger,
+) (processedResources, failedResources, skippedResources []ResourceResult, err error) {
 
-	processedResources := make([]ResourceResult, 0)
+	processedResources = make([]ResourceResult, 0)
 	var failedActions []error // Slice to store all errors
 
-	reportUpdatedCount(cleanerName, float64(len(resources)))
+	resources, skippedResources = skipProtectedResources(ctx, cleanerName, resources, logger)
+
+	if !dryRun {
+		reportUpdatedCount(cleanerName, float64(len(resources)))
+	}
 
 	numberOfErrors := 0
 	for i := range resources {
@@ -413,21 +614,40 @@ func updateMatchingResources(ctx context.Context, cleanerName string, resources
 			resource.Resource.GetNamespace(),
 			resource.Resource.GetName()))
 		l.Info("updating resource")
-		newResource, err := transform(resource.Resource, transformFunction, l)
+		result, err := runTransform(ctx, resource.Resource, transformFunction, luaLimits, l)
 		if err != nil {
 			numberOfErrors++
 			reportErrorEvent(cleanerName, resource.Resource.GetAPIVersion(),
 				resource.Resource.GetKind())
 			l.Info(fmt.Sprintf("failed to transform resource: %v", err))
 			failedActions = append(failedActions, err)
+			failedResources = append(failedResources, ResourceResult{Resource: resource.Resource, Message: err.Error()})
 			continue
 		}
-		if err := k8sClient.Update(ctx, newResource); err != nil {
+		waitForAction(ctx)
+		newResource, err := applyTransform(ctx, resource.Resource, result, transformOptions, dryRun)
+		if err != nil {
 			numberOfErrors++
 			reportErrorEvent(cleanerName, resource.Resource.GetAPIVersion(),
 				resource.Resource.GetKind())
 			l.Info(fmt.Sprintf("failed to update resource: %v", err))
-			failedActions = append(failedActions, err)
+			// Conflicts (e.g. another field manager owning a field this
+			// transform sets) are only actionable if the resource is named.
+			failedActions = append(failedActions, fmt.Errorf("%s %s/%s: %w", resource.Resource.GetKind(),
+				resource.Resource.GetNamespace(), resource.Resource.GetName(), err))
+			failedResources = append(failedResources, ResourceResult{Resource: resource.Resource, Message: err.Error()})
+			continue
+		}
+		if dryRun {
+			// On a dry-run request, newResource holds what the API server would
+			// have persisted, so the diff also reflects defaulting and mutating
+			// admission webhooks.
+			diff, err := computeDiff(resource.Resource, newResource)
+			if err != nil {
+				l.Info(fmt.Sprintf("failed to compute dry-run diff: %v", err))
+			}
+			resource.Diff = diff
+			processedResources = append(processedResources, resource)
 			continue
 		}
 		processedResources = append(processedResources, resource)
@@ -437,64 +657,120 @@ func updateMatchingResources(ctx context.Context, cleanerName string, resources
 
 	if len(failedActions) > 0 {
 		// Use errors.Join to combine all collected errors into a single error
-		return processedResources, errors.Join(failedActions...)
+		return processedResources, failedResources, skippedResources, errors.Join(failedActions...)
 	}
 
 	reportErrorCount(cleanerName, float64(numberOfErrors))
 
-	return processedResources, nil
+	return processedResources, failedResources, skippedResources, nil
 }
 
+// fetchResources returns all resources selected by resourceSelector.
 func fetchResources(ctx context.Context, resourceSelector *appsv1alpha1.ResourceSelector,
 	logger logr.Logger) ([]unstructured.Unstructured, error) {
 
+	var result []unstructured.Unstructured
+	err := visitResources(ctx, resourceSelector, logger, func(resource *unstructured.Unstructured) error {
+		result = append(result, *resource)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// visitResources calls visit for every resource selected by resourceSelector.
+// Resources are listed from the API server page by page, so only one page is
+// held in memory at a time (besides whatever visit keeps).
+func visitResources(ctx context.Context, resourceSelector *appsv1alpha1.ResourceSelector,
+	logger logr.Logger, visit func(*unstructured.Unstructured) error) error {
+
 	gvk := schema.GroupVersionKind{
 		Group:   resourceSelector.Group,
 		Version: resourceSelector.Version,
 		Kind:    resourceSelector.Kind,
 	}
 
-	dc := discovery.NewDiscoveryClientForConfigOrDie(config)
-	groupResources, err := restmapper.GetAPIGroupResources(dc)
-	if err != nil {
-		return nil, err
-	}
-	mapper := restmapper.NewDiscoveryRESTMapper(groupResources)
-
-	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	resourceId, err := getResourceForKind(config, gvk)
 	if err != nil {
 		if meta.IsNoMatchError(err) {
-			return nil, nil
+			return nil
 		}
-		return nil, err
+		return err
 	}
 
-	resourceId := schema.GroupVersionResource{
-		Group:    gvk.Group,
-		Version:  gvk.Version,
-		Resource: mapping.Resource.Resource,
+	options := metav1.ListOptions{
+		Limit: getPageSize(resourceSelector),
 	}
 
-	options := metav1.ListOptions{}
-
 	if len(resourceSelector.LabelFilters) > 0 {
 		options.LabelSelector = addLabelFilters(resourceSelector.LabelFilters)
 	}
+	options.FieldSelector = resourceSelector.FieldSelector
 
 	var namespaces []string
 	namespaces, err = getNamespaces(ctx, resourceSelector, logger)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	var result []unstructured.Unstructured
+	excluded, err := getExcludedNamespaces(ctx, resourceSelector, logger)
+	if err != nil {
+		return err
+	}
+	if len(excluded) > 0 {
+		selectedVisit := visit
+		visit = func(resource *unstructured.Unstructured) error {
+			if excluded[resource.GetNamespace()] {
+				return nil
+			}
+			return selectedVisit(resource)
+		}
+	}
+
+	if resourceSelector.UseCache && informers != nil {
+		selector, err := labels.Parse(options.LabelSelector)
+		if err != nil {
+			return err
+		}
+		fieldSelector, err := fields.ParseSelector(options.FieldSelector)
+		if err != nil {
+			return err
+		}
+		result, ok, err := informers.list(resourceId, namespaces, selector)
+		if ok {
+			if err != nil {
+				return err
+			}
+			for i := range result {
+				// The API server applies field selectors when listing; the
+				// cache does not.
+				if !matchesFieldSelector(fieldSelector, &result[i]) {
+					continue
+				}
+				if err := visit(&result[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	d := dynamic.NewForConfigOrDie(config)
 	if len(namespaces) > 0 {
-		result, err = collectFromNamespaces(ctx, config, namespaces, &resourceId, &options)
-	} else {
-		result, err = collectWithOptions(ctx, config, &resourceId, &options)
+		return visitFromNamespaces(ctx, d, namespaces, &resourceId, &options, visit)
 	}
+	return visitWithOptions(ctx, d, &resourceId, &options, visit)
+}
 
-	return result, err
+// getPageSize returns how many resources are requested per List call.
+func getPageSize(resourceSelector *appsv1alpha1.ResourceSelector) int64 {
+	if resourceSelector.PageSize != nil && *resourceSelector.PageSize > 0 {
+		return *resourceSelector.PageSize
+	}
+	return defaultPageSize
 }
 
 func addLabelFilters(labelFilters []libsveltosv1beta1.LabelFilter) string {
@@ -523,38 +799,61 @@ func addLabelFilters(labelFilters []libsveltosv1beta1.LabelFilter) string {
 	return labelFilter
 }
 
-func collectFromNamespaces(ctx context.Context, config *rest.Config, namespaces []string,
-	resourceId *schema.GroupVersionResource, options *metav1.ListOptions) ([]unstructured.Unstructured, error) {
+func visitFromNamespaces(ctx context.Context, d dynamic.Interface, namespaces []string,
+	resourceId *schema.GroupVersionResource, options *metav1.ListOptions,
+	visit func(*unstructured.Unstructured) error) error {
 
-	result := make([]unstructured.Unstructured, 0)
 	for i := range namespaces {
 		tmpOptions := *options
-		tmpOptions.FieldSelector += fmt.Sprintf("metadata.namespace=%s", namespaces[i])
-		tmpResult, err := collectWithOptions(ctx, config, resourceId, &tmpOptions)
-		if err != nil {
-			return nil, err
+		namespaceSelector := fmt.Sprintf("metadata.namespace=%s", namespaces[i])
+		if tmpOptions.FieldSelector == "" {
+			tmpOptions.FieldSelector = namespaceSelector
+		} else {
+			tmpOptions.FieldSelector += "," + namespaceSelector
+		}
+		if err := visitWithOptions(ctx, d, resourceId, &tmpOptions, visit); err != nil {
+			return err
 		}
-
-		result = append(result, tmpResult...)
 	}
 
-	return result, nil
+	return nil
 }
 
-func collectWithOptions(ctx context.Context, config *rest.Config,
-	resourceId *schema.GroupVersionResource, options *metav1.ListOptions) ([]unstructured.Unstructured, error) {
+// visitWithOptions lists resources using options.Limit as page size, following
+// continue tokens until all pages have been visited. Visiting a page can take
+// longer than a continue token is valid for: when the API server reports it
+// expired, the list is started over, skipping resources already visited.
+func visitWithOptions(ctx context.Context, d dynamic.Interface,
+	resourceId *schema.GroupVersionResource, options *metav1.ListOptions,
+	visit func(*unstructured.Unstructured) error) error {
 
-	d := dynamic.NewForConfigOrDie(config)
-	list, err := d.Resource(*resourceId).List(ctx, *options)
-	if err != nil {
-		return nil, err
-	}
-
-	result := make([]unstructured.Unstructured, len(list.Items))
+	visited := make(map[types.UID]bool)
+	pageOptions := *options
+	for {
+		list, err := d.Resource(*resourceId).List(ctx, pageOptions)
+		if err != nil {
+			if apierrors.IsResourceExpired(err) && pageOptions.Continue != "" {
+				pageOptions.Continue = ""
+				continue
+			}
+			return err
+		}
 
-	copy(result, list.Items)
+		for i := range list.Items {
+			if visited[list.Items[i].GetUID()] {
+				continue
+			}
+			visited[list.Items[i].GetUID()] = true
+			if err := visit(&list.Items[i]); err != nil {
+				return err
+			}
+		}
 
-	return result, nil
+		pageOptions.Continue = list.GetContinue()
+		if pageOptions.Continue == "" {
+			return nil
+		}
+	}
 }
 
 // getNamespaces returns all namespaces to consider:
@@ -610,6 +909,39 @@ func getNamespaces(ctx context.Context, resourceSelector *appsv1alpha1.ResourceS
 	return matchingNamespaces, nil
 }
 
+// getExcludedNamespaces returns the namespaces listed in ExcludeNamespaces
+// along with the ones matching ExcludeNamespaceSelector.
+func getExcludedNamespaces(ctx context.Context, resourceSelector *appsv1alpha1.ResourceSelector,
+	logger logr.Logger) (map[string]bool, error) {
+
+	excluded := make(map[string]bool)
+	for i := range resourceSelector.ExcludeNamespaces {
+		excluded[resourceSelector.ExcludeNamespaces[i]] = true
+	}
+
+	if resourceSelector.ExcludeNamespaceSelector == "" {
+		return excluded, nil
+	}
+
+	parsedSelector, err := labels.Parse(resourceSelector.ExcludeNamespaceSelector)
+	if err != nil {
+		logger.V(logs.LogInfo).Info(fmt.Sprintf("failed to parse ExcludeNamespaceSelector: %v", err))
+		return nil, err
+	}
+
+	namespaces := &corev1.NamespaceList{}
+	err = k8sClient.List(ctx, namespaces, client.MatchingLabelsSelector{Selector: parsedSelector})
+	if err != nil {
+		logger.Error(err, "failed to list namespaces")
+		return nil, err
+	}
+	for i := range namespaces.Items {
+		excluded[namespaces.Items[i].Name] = true
+	}
+
+	return excluded, nil
+}
+
 // setEvaluateGlobals sets every global an Evaluate script may reference besides
 // obj: metrics (MetricSource/MetricQueries), events (IncludeEvents), and
 // logs/logsByContainer/logsPrevious/logsPreviousByContainer (LogSource). Each
@@ -643,57 +975,97 @@ func setEvaluateGlobals(l *lua.LState, metricsData map[string]float64, events []
 	l.SetGlobal("logsPreviousByContainer", logsByContainerTable(l, previousLogs))
 }
 
-func isMatch(resource *unstructured.Unstructured, script string, metricsData map[string]float64,
+func isMatch(ctx context.Context, resource *unstructured.Unstructured, script string,
+	luaLimits *appsv1alpha1.LuaLimits, lookups *lookupCache, metricsData map[string]float64,
 	events []corev1.Event, currentLogs, previousLogs *containerLogTails, logger logr.Logger,
 ) (matching bool, message string, err error) {
 
+	result, err := runEvaluate(ctx, resource, script, luaLimits, lookups, metricsData, events,
+		currentLogs, previousLogs, logger)
+	if err != nil {
+		return false, "", err
+	}
+	return result.Matching, result.Message, nil
+}
+
+// runEvaluate runs the evaluate function on resource and returns its result.
+// An empty script matches every resource.
+func runEvaluate(ctx context.Context, resource *unstructured.Unstructured, script string,
+	luaLimits *appsv1alpha1.LuaLimits, lookups *lookupCache, metricsData map[string]float64,
+	events []corev1.Event, currentLogs, previousLogs *containerLogTails, logger logr.Logger,
+) (*evaluateStatus, error) {
+
+	return runEvaluateWithGlobals(ctx, resource, script, luaLimits, lookups, nil, func(l *lua.LState) {
+		setEvaluateGlobals(l, metricsData, events, currentLogs, previousLogs)
+	}, logger)
+}
+
+// runEvaluateWithGlobals is runEvaluate with the globals, besides obj and the
+// lookup functions, set by setGlobals. The Lua state is taken from pool, if
+// not nil.
+func runEvaluateWithGlobals(ctx context.Context, resource *unstructured.Unstructured, script string,
+	luaLimits *appsv1alpha1.LuaLimits, lookups *lookupCache, pool *luaSandboxPool, setGlobals func(l *lua.LState),
+	logger logr.Logger) (status *evaluateStatus, err error) {
+
 	if script == "" {
-		return true, "", nil
+		return &evaluateStatus{Matching: true}, nil
 	}
 
-	l := lua.NewState()
-	defer l.Close()
+	var l *luaSandbox
+	if pool != nil {
+		l = pool.get(ctx)
+		defer func() { pool.put(l, err != nil) }()
+	} else {
+		l = newLuaSandbox(ctx, luaLimits)
+		defer l.Close()
+	}
 
-	setEvaluateGlobals(l, metricsData, events, currentLogs, previousLogs)
+	setGlobals(l.LState)
+	if lookups == nil {
+		lookups = newLookupCache(k8sClient)
+	}
+	// API calls made by the script count against its timeout.
+	setLookupFunctions(l.ctx, l.LState, lookups)
 
 	obj := mapToTable(resource.UnstructuredContent())
 
-	if err = l.DoString(script); err != nil {
+	if err := loadScript(l.LState, script); err != nil {
+		err = l.wrapError(err)
 		logger.Info(fmt.Sprintf("doString failed: %v", err))
-		return false, "", err
+		return nil, err
 	}
 
 	l.SetGlobal("obj", obj)
 
-	if err = l.CallByParam(lua.P{
+	if err := l.CallByParam(lua.P{
 		Fn:      l.GetGlobal("evaluate"), // name of Lua function
 		NRet:    1,                       // number of returned values
 		Protect: true,                    // return err or panic
 	}, obj); err != nil {
+		err = l.wrapError(err)
 		logger.Info(fmt.Sprintf("failed to evaluate health for resource: %v", err))
-		return false, "", err
+		return nil, err
 	}
 
 	lv := l.Get(-1)
 	tbl, ok := lv.(*lua.LTable)
 	if !ok {
 		logger.Info(luaTableError)
-		return false, "", fmt.Errorf("%s", luaTableError)
+		return nil, fmt.Errorf("%s", luaTableError)
 	}
 
 	goResult := toGoValue(tbl)
-	var resultJson []byte
-	resultJson, err = json.Marshal(goResult)
+	resultJson, err := json.Marshal(goResult)
 	if err != nil {
 		logger.Info(fmt.Sprintf("failed to marshal result: %v", err))
-		return false, "", err
+		return nil, err
 	}
 
 	var result evaluateStatus
 	err = json.Unmarshal(resultJson, &result)
 	if err != nil {
 		logger.Info(fmt.Sprintf("failed to marshal result: %v", err))
-		return false, "", err
+		return nil, err
 	}
 
 	if result.Message != "" {
@@ -702,22 +1074,39 @@ func isMatch(resource *unstructured.Unstructured, script string, metricsData map
 
 	logger.V(logs.LogDebug).Info(fmt.Sprintf("is a match: %t", result.Matching))
 
-	return result.Matching, result.Message, nil
+	return &result, nil
+}
+
+// transform returns the new object computed by the transform function. It only
+// supports transform functions returning a full "resource".
+func transform(ctx context.Context, resource *unstructured.Unstructured, script string,
+	luaLimits *appsv1alpha1.LuaLimits, logger logr.Logger) (*unstructured.Unstructured, error) {
+
+	result, err := runTransform(ctx, resource, script, luaLimits, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Resource, nil
 }
 
-func transform(resource *unstructured.Unstructured, script string, logger logr.Logger,
-) (*unstructured.Unstructured, error) {
+// runTransform invokes the transform function on resource and returns its
+// output: either a full resource, a JSON patch, a merge patch or a partial
+// object for server-side apply.
+func runTransform(ctx context.Context, resource *unstructured.Unstructured, script string,
+	luaLimits *appsv1alpha1.LuaLimits, logger logr.Logger) (*transformStatus, error) {
 
 	if script == "" {
-		return resource, nil
+		return &transformStatus{Resource: resource}, nil
 	}
 
-	l := lua.NewState()
+	l := newLuaSandbox(ctx, luaLimits)
 	defer l.Close()
 
 	obj := mapToTable(resource.UnstructuredContent())
 
-	if err := l.DoString(script); err != nil {
+	if err := loadScript(l.LState, script); err != nil {
+		err = l.wrapError(err)
 		logger.Info(fmt.Sprintf("doString failed: %v", err))
 		return nil, err
 	}
@@ -729,6 +1118,7 @@ func transform(resource *unstructured.Unstructured, script string, logger logr.L
 		NRet:    1,                        // number of returned values
 		Protect: true,                     // return err or panic
 	}, obj); err != nil {
+		err = l.wrapError(err)
 		logger.Info(fmt.Sprintf("failed to evaluate health for resource: %v", err))
 		return nil, err
 	}
@@ -758,20 +1148,22 @@ func transform(resource *unstructured.Unstructured, script string, logger logr.L
 		logger.Info(fmt.Sprintf("message: %s", result.Message))
 	}
 
-	return result.Resource, nil
+	return &result, nil
 }
 
-func aggregatedSelection(luaScript string, resources []ResourceResult, logger logr.Logger) ([]ResourceResult, error) {
+func aggregatedSelection(ctx context.Context, luaScript string, resources []ResourceResult,
+	luaLimits *appsv1alpha1.LuaLimits, logger logr.Logger) ([]ResourceResult, error) {
 	if luaScript == "" {
 		return resources, nil
 	}
 
-	// Create a new Lua state
-	l := lua.NewState()
+	// Create a new sandboxed Lua state
+	l := newLuaSandbox(ctx, luaLimits)
 	defer l.Close()
 
 	// Load the Lua script
-	if err := l.DoString(luaScript); err != nil {
+	if err := loadScript(l.LState, luaScript); err != nil {
+		err = l.wrapError(err)
 		logger.V(logs.LogInfo).Info(fmt.Sprintf("doString failed: %v", err))
 		return nil, err
 	}
@@ -790,6 +1182,7 @@ func aggregatedSelection(luaScript string, resources []ResourceResult, logger lo
 		NRet:    1,                       // number of returned values
 		Protect: true,                    // return err or panic
 	}, argTable); err != nil {
+		err = l.wrapError(err)
 		logger.V(logs.LogInfo).Info(fmt.Sprintf("failed to call evaluate function: %s", err.Error()))
 		return nil, err
 	}
@@ -845,7 +1238,7 @@ func getCleanerInstance(ctx context.Context, cleanerName string) (*appsv1alpha1.
 // - set results for further in time lookup
 // - remove request from inProgress
 // - if request is in dirty, remove it from there and add it to the back of the jobQueue
-func storeResult(cleanerName string, err error, logger logr.Logger) {
+func storeResult(cleanerName string, stats *runStats, err error, logger logr.Logger) {
 	managerInstance.mu.Lock()
 	defer managerInstance.mu.Unlock()
 
@@ -867,6 +1260,7 @@ func storeResult(cleanerName string, err error, logger logr.Logger) {
 		logger.V(logs.LogDebug).Info("added to result")
 	}
 	managerInstance.results[key] = err
+	managerInstance.runStats[key] = stats
 
 	// if key is in dirty, remove from there and push to jobQueue
 	for i := range managerInstance.dirty {
@@ -879,10 +1273,34 @@ func storeResult(cleanerName string, err error, logger logr.Logger) {
 		managerInstance.dirty = removeFromSlice(managerInstance.dirty, i)
 		logger.V(logs.LogDebug).Info("remove result")
 		delete(managerInstance.results, key)
+		delete(managerInstance.runStats, key)
 		break
 	}
 }
 
+// storeChangeResult sets the result of a run triggered by a change, so that
+// the status of Cleaner cleanerName reflects it, unless a scheduled run is
+// pending or its result was not collected yet: that result takes precedence.
+func storeChangeResult(cleanerName string, stats *runStats, err error) {
+	if managerInstance == nil {
+		return
+	}
+
+	managerInstance.mu.Lock()
+	defer managerInstance.mu.Unlock()
+
+	key := cleanerName
+	if _, ok := managerInstance.results[key]; ok ||
+		slices.Contains(managerInstance.inProgress, key) ||
+		slices.Contains(managerInstance.jobQueue, key) ||
+		slices.Contains(managerInstance.dirty, key) {
+		return
+	}
+
+	managerInstance.results[key] = err
+	managerInstance.runStats[key] = stats
+}
+
 // getRequestStatus gets requests status.
 // If result is available it returns the result.
 // If request is still queued, responseParams is nil and an error is nil.
@@ -903,9 +1321,11 @@ func getRequestStatus(cleanerName string) (*responseParams, error) {
 		resp := responseParams{
 			cleanerName: key,
 			err:         managerInstance.results[key],
+			stats:       managerInstance.runStats[key],
 		}
 		logger.V(logs.LogDebug).Info("removing result")
 		delete(managerInstance.results, key)
+		delete(managerInstance.runStats, key)
 		return &resp, nil
 	}
 
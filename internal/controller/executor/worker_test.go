@@ -28,8 +28,15 @@ import (
 	"go.uber.org/zap"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
 
 	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
 	"gianlucam76/k8s-cleaner/internal/controller/executor"
@@ -351,7 +358,7 @@ var _ = Describe("Worker", func() {
 		}
 		logger, err := zap.NewDevelopment()
 		Expect(err).To(BeNil())
-		resources, totalScanned, err := executor.GetMatchingResources(context.TODO(), matchingResources,
+		resources, totalScanned, err := executor.GetMatchingResources(context.TODO(), matchingResources, nil, nil, nil,
 			zapr.NewLogger(logger))
 		Expect(err).To(BeNil())
 		Expect(resources).ToNot(BeNil())
@@ -362,6 +369,131 @@ var _ = Describe("Worker", func() {
 		Expect(resources[0].Resource.GetName()).To(Equal(secret.Name))
 	})
 
+	It("getMatchingResources pages through resources keeping only matches", func() {
+		value := randomString()
+		for i := 0; i < 5; i++ {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: ns.Name,
+					Name:      randomString(),
+				},
+			}
+			if i%2 == 0 {
+				secret.Labels = map[string]string{"foo": value}
+			}
+			Expect(k8sClient.Create(context.TODO(), secret)).To(Succeed())
+		}
+
+		evaluate := fmt.Sprintf(`function evaluate()
+   local hs = {}
+   hs.matching = obj.metadata.labels ~= nil and obj.metadata.labels["foo"] == %q
+   return hs
+   end
+   `, value)
+
+		pageSize := int64(1)
+		matchingResources := &appsv1alpha1.ResourceSelector{
+			Kind:      kindSecret,
+			Group:     "",
+			Version:   apiVersionV1,
+			Namespace: ns.Name,
+			Evaluate:  evaluate,
+			PageSize:  &pageSize,
+		}
+		resources, totalScanned, err := executor.GetMatchingResources(context.TODO(), matchingResources, nil, nil, nil,
+			logr.Logger{})
+		Expect(err).To(BeNil())
+		Expect(totalScanned).To(Equal(5))
+		Expect(len(resources)).To(Equal(3))
+
+		list, err := executor.FetchResources(context.TODO(), matchingResources, logr.Logger{})
+		Expect(err).To(BeNil())
+		Expect(len(list)).To(Equal(5))
+	})
+
+	It("visitWithOptions starts the list over when a continue token expires", func() {
+		configMapGVR := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+		newItem := func(name string) unstructured.Unstructured {
+			u := unstructured.Unstructured{}
+			u.SetAPIVersion(apiVersionV1)
+			u.SetKind(kindConfigMap)
+			u.SetNamespace(ns.Name)
+			u.SetName(name)
+			u.SetUID(types.UID(name))
+			return u
+		}
+
+		d := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{configMapGVR: "ConfigMapList"})
+		// Root List actions do not carry the continue token: pages are told
+		// apart by the order of calls. The second call, for the second page,
+		// fails with 410 Expired; the list is then started over.
+		calls := 0
+		d.PrependReactor("list", "configmaps", func(_ clienttesting.Action) (bool, runtime.Object, error) {
+			calls++
+			list := &unstructured.UnstructuredList{}
+			list.SetAPIVersion(apiVersionV1)
+			list.SetKind("ConfigMapList")
+			switch calls {
+			case 2:
+				return true, nil, apierrors.NewResourceExpired("continue token expired")
+			case 1, 3:
+				list.Items = []unstructured.Unstructured{newItem("first"), newItem("second")}
+				list.SetContinue("page-2")
+			default:
+				list.Items = []unstructured.Unstructured{newItem("third")}
+			}
+			return true, list, nil
+		})
+
+		var visited []string
+		Expect(executor.VisitWithOptions(context.TODO(), d, &configMapGVR, &metav1.ListOptions{Limit: 2},
+			func(u *unstructured.Unstructured) error {
+				visited = append(visited, u.GetName())
+				return nil
+			})).To(Succeed())
+		Expect(calls).To(Equal(4))
+		Expect(visited).To(Equal([]string{"first", "second", "third"}))
+	})
+
+	It("getMatchingResources evaluates resources concurrently, keeping listing order", func() {
+		names := make([]string, 20)
+		for i := range names {
+			names[i] = fmt.Sprintf("secret-%02d", i)
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: ns.Name,
+					Name:      names[i],
+				},
+			}
+			Expect(k8sClient.Create(context.TODO(), secret)).To(Succeed())
+		}
+
+		matchingResources := &appsv1alpha1.ResourceSelector{
+			Kind:      kindSecret,
+			Group:     "",
+			Version:   apiVersionV1,
+			Namespace: ns.Name,
+			Evaluate: `function evaluate()
+   local hs = {}
+   hs.matching = tonumber(string.sub(obj.metadata.name, -2)) % 3 == 0
+   return hs
+   end
+   `,
+		}
+		resources, totalScanned, err := executor.GetMatchingResources(context.TODO(), matchingResources, nil,
+			ptr.To(int32(8)), nil, logr.Logger{})
+		Expect(err).To(BeNil())
+		Expect(totalScanned).To(Equal(len(names)))
+
+		matched := make([]string, len(resources))
+		for i := range resources {
+			matched[i] = resources[i].Resource.GetName()
+		}
+		Expect(matched).To(Equal([]string{names[0], names[3], names[6], names[9], names[12],
+			names[15], names[18]}))
+	})
+
 	It("getMatchingResources evaluates using the events global when IncludeEvents is set", func() {
 		sa := &corev1.ServiceAccount{
 			ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name, Name: randomString()},
@@ -404,7 +536,7 @@ var _ = Describe("Worker", func() {
 		var resources []executor.ResourceResult
 		Eventually(func() bool {
 			var err error
-			resources, _, err = executor.GetMatchingResources(context.TODO(), resourceSelector, logr.Logger{})
+			resources, _, err = executor.GetMatchingResources(context.TODO(), resourceSelector, nil, nil, nil, logr.Logger{})
 			return err == nil && len(resources) == 1
 		}, timeout, pollingInterval).Should(BeTrue())
 		Expect(resources[0].Resource.GetName()).To(Equal(sa.Name))
@@ -431,7 +563,7 @@ var _ = Describe("Worker", func() {
 
 		// LogSource is meaningless for a ServiceAccount selector; this must not
 		// error, just proceed as if LogSource were unset.
-		resources, _, err := executor.GetMatchingResources(context.TODO(), resourceSelector, logr.Logger{})
+		resources, _, err := executor.GetMatchingResources(context.TODO(), resourceSelector, nil, nil, nil, logr.Logger{})
 		Expect(err).ToNot(HaveOccurred())
 		Expect(resources).To(HaveLen(1))
 		Expect(resources[0].Resource.GetName()).To(Equal(sa.Name))
@@ -19,6 +19,7 @@ package controller
 var (
 	ShouldSchedule      = shouldSchedule
 	GetNextScheduleTime = getNextScheduleTime
+	SetRunStatus        = setRunStatus
 
 	AddFinalizer = (*CleanerReconciler).addFinalizer
 	RemoveReport = (*CleanerReconciler).removeReport
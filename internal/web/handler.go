@@ -41,6 +41,7 @@ func setupRoutes(c client.Client, readOnly bool, version string, log logr.Logger
 	mux.HandleFunc("GET /api/v1/library/{id}", GetLibraryEntryHandler(log))
 	mux.HandleFunc("GET /api/v1/reports", ListReportsHandler(c, log))
 	mux.HandleFunc("GET /api/v1/reports/{name}", GetReportHandler(c, log))
+	mux.HandleFunc("GET /api/v1/reports/{name}/history", ReportHistoryHandler(c, log))
 	mux.HandleFunc("POST /api/v1/reports/{name}/rollback", RollbackHandler(c, log))
 	mux.HandleFunc("POST /api/v1/cleaners/{name}/trigger", TriggerHandler(c, log))
 	mux.HandleFunc("POST /api/v1/trigger-all", TriggerAllHandler(c, log))
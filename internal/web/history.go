@@ -0,0 +1,149 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"gianlucam76/k8s-cleaner/internal/controller/executor"
+)
+
+const (
+	defaultHistoryLimit = 20
+	maxHistoryLimit     = 100
+)
+
+// historyResponse is a page of past runs of a Cleaner, newest first.
+type historyResponse struct {
+	Total  int                 `json:"total"`
+	Limit  int                 `json:"limit"`
+	Offset int                 `json:"offset"`
+	Items  []executionResponse `json:"items"`
+}
+
+// executionResponse is the JSON representation of an ExecutionRecord.
+type executionResponse struct {
+	Name                string           `json:"name"`
+	StartTime           time.Time        `json:"startTime"`
+	EndTime             time.Time        `json:"endTime"`
+	Action              string           `json:"action"`
+	DryRun              bool             `json:"dryRun,omitempty"`
+	ScannedCount        int              `json:"scannedCount"`
+	MatchedCount        int              `json:"matchedCount"`
+	ProcessedCount      int              `json:"processedCount"`
+	FailedCount         int              `json:"failedCount"`
+	SkippedCount        int              `json:"skippedCount,omitempty"`
+	DeferredCount       int              `json:"deferredCount,omitempty"`
+	BlastRadiusExceeded bool             `json:"blastRadiusExceeded,omitempty"`
+	FailureMessage      string           `json:"failureMessage,omitempty"`
+	Resources           []executionEntry `json:"resources"`
+	ResourcesTruncated  bool             `json:"resourcesTruncated,omitempty"`
+}
+
+// executionEntry is the outcome of a run on a single resource.
+type executionEntry struct {
+	resourceItem
+	Outcome string `json:"outcome"`
+}
+
+// ReportHistoryHandler returns the past runs of a Cleaner, as recorded in
+// ExecutionRecords, newest first.
+// Query params: ?limit=N&offset=M
+func ReportHistoryHandler(c client.Client, log logr.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		name := r.PathValue("name")
+
+		limit, err := queryInt(r, "limit", defaultHistoryLimit)
+		if err != nil || limit <= 0 {
+			respondError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		limit = min(limit, maxHistoryLimit)
+
+		offset, err := queryInt(r, "offset", 0)
+		if err != nil || offset < 0 {
+			respondError(w, http.StatusBadRequest, "offset must be a non-negative integer")
+			return
+		}
+
+		records, err := executor.ListExecutionRecords(ctx, c, name)
+		if err != nil {
+			log.Error(err, "failed to list execution records", "name", name)
+			respondError(w, http.StatusInternalServerError, "failed to list execution records")
+			return
+		}
+
+		resp := historyResponse{
+			Total:  len(records),
+			Limit:  limit,
+			Offset: offset,
+			Items:  make([]executionResponse, 0, limit),
+		}
+		for i := offset; i < len(records) && i < offset+limit; i++ {
+			spec := &records[i].Spec
+			item := executionResponse{
+				Name:                records[i].Name,
+				StartTime:           spec.StartTime.Time,
+				EndTime:             spec.EndTime.Time,
+				Action:              string(spec.Action),
+				DryRun:              spec.DryRun,
+				ScannedCount:        spec.ScannedCount,
+				MatchedCount:        spec.MatchedCount,
+				ProcessedCount:      spec.ProcessedCount,
+				FailedCount:         spec.FailedCount,
+				SkippedCount:        spec.SkippedCount,
+				DeferredCount:       spec.DeferredCount,
+				BlastRadiusExceeded: spec.BlastRadiusExceeded,
+				FailureMessage:      spec.FailureMessage,
+				ResourcesTruncated:  spec.ResourcesTruncated,
+				Resources:           make([]executionEntry, 0, len(spec.Resources)),
+			}
+			for j := range spec.Resources {
+				ref := spec.Resources[j].Resource
+				item.Resources = append(item.Resources, executionEntry{
+					resourceItem: resourceItem{
+						Kind:       ref.Kind,
+						Namespace:  ref.Namespace,
+						Name:       ref.Name,
+						APIVersion: ref.APIVersion,
+						Message:    spec.Resources[j].Message,
+					},
+					Outcome: string(spec.Resources[j].Outcome),
+				})
+			}
+			resp.Items = append(resp.Items, item)
+		}
+
+		respondJSON(w, http.StatusOK, resp)
+	}
+}
+
+// queryInt returns the integer value of query parameter key, or def when
+// the parameter is not set.
+func queryInt(r *http.Request, key string, def int) (int, error) {
+	v := r.URL.Query().Get(key)
+	if v == "" {
+		return def, nil
+	}
+	return strconv.Atoi(v)
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+)
+
+func newTestExecutionRecord(cleanerName string, run int, start time.Time) *appsv1alpha1.ExecutionRecord {
+	return &appsv1alpha1.ExecutionRecord{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   fmt.Sprintf("%s-%d", cleanerName, run),
+			Labels: map[string]string{appsv1alpha1.ExecutionRecordCleanerLabel: cleanerName},
+		},
+		Spec: appsv1alpha1.ExecutionRecordSpec{
+			CleanerName:    cleanerName,
+			StartTime:      metav1.NewTime(start),
+			EndTime:        metav1.NewTime(start.Add(time.Second)),
+			Action:         appsv1alpha1.ActionDelete,
+			ProcessedCount: 1,
+			Resources: []appsv1alpha1.ExecutionRecordResource{
+				{
+					Resource: corev1.ObjectReference{Kind: kindConfigMap, Namespace: namespaceDefault, Name: resourceNameOld},
+					Outcome:  appsv1alpha1.ResourceOutcomeProcessed,
+				},
+			},
+		},
+	}
+}
+
+var _ = Describe("Report history", func() {
+	var c client.Client
+
+	BeforeEach(func() {
+		now := time.Now().Truncate(time.Second)
+		objects := []client.Object{newTestExecutionRecord("other", 0, now)}
+		for i := range 5 {
+			objects = append(objects, newTestExecutionRecord("history", i, now.Add(time.Duration(i)*time.Minute)))
+		}
+		c = fake.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(objects...).Build()
+	})
+
+	getHistory := func(query string) (*httptest.ResponseRecorder, historyResponse) {
+		handler := testHandler(c, false)
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/reports/history/history"+query, http.NoBody)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		var resp historyResponse
+		if w.Code == http.StatusOK {
+			Expect(json.NewDecoder(w.Body).Decode(&resp)).To(Succeed())
+		}
+		return w, resp
+	}
+
+	It("returns only the runs of the requested Cleaner, newest first", func() {
+		w, resp := getHistory("")
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(resp.Total).To(Equal(5))
+		Expect(resp.Items).To(HaveLen(5))
+		Expect(resp.Items[0].Name).To(Equal("history-4"))
+		Expect(resp.Items[4].Name).To(Equal("history-0"))
+		Expect(resp.Items[0].Resources).To(HaveLen(1))
+		Expect(resp.Items[0].Resources[0].Outcome).To(Equal(string(appsv1alpha1.ResourceOutcomeProcessed)))
+	})
+
+	It("pages through runs with limit and offset", func() {
+		w, resp := getHistory("?limit=2&offset=2")
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(resp.Total).To(Equal(5))
+		Expect(resp.Items).To(HaveLen(2))
+		Expect(resp.Items[0].Name).To(Equal("history-2"))
+		Expect(resp.Items[1].Name).To(Equal("history-1"))
+
+		_, resp = getHistory("?limit=2&offset=10")
+		Expect(resp.Items).To(BeEmpty())
+	})
+
+	It("rejects invalid paging parameters", func() {
+		w, _ := getHistory("?limit=0")
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+
+		w, _ = getHistory("?offset=-1")
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+	})
+})
@@ -30,6 +30,7 @@ import (
 type reportResponse struct {
 	Name      string         `json:"name"`
 	Action    string         `json:"action"`
+	DryRun    bool           `json:"dryRun,omitempty"`
 	Resources []resourceItem `json:"resources"`
 }
 
@@ -40,6 +41,7 @@ type resourceItem struct {
 	Name       string `json:"name"`
 	APIVersion string `json:"apiVersion"`
 	Message    string `json:"message,omitempty"`
+	Diff       string `json:"diff,omitempty"`
 }
 
 // ListReportsHandler returns all reports, with optional filtering.
@@ -107,6 +109,7 @@ func toReportResponse(report *appsv1alpha1.Report, filterNS, filterKind string)
 	resp := reportResponse{
 		Name:   report.Name,
 		Action: string(report.Spec.Action),
+		DryRun: report.Spec.DryRun,
 	}
 
 	resources := make([]resourceItem, 0, len(report.Spec.ResourceInfo))
@@ -126,6 +129,7 @@ func toReportResponse(report *appsv1alpha1.Report, filterNS, filterKind string)
 			Name:       ref.Name,
 			APIVersion: ref.APIVersion,
 			Message:    report.Spec.ResourceInfo[i].Message,
+			Diff:       report.Spec.ResourceInfo[i].Diff,
 		})
 	}
 	resp.Resources = resources
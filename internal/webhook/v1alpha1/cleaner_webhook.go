@@ -0,0 +1,168 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+	"gianlucam76/k8s-cleaner/internal/controller/executor"
+)
+
+// SetupCleanerWebhookWithManager registers the validating webhook for
+// Cleaner with mgr.
+func SetupCleanerWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &appsv1alpha1.Cleaner{}).
+		WithValidator(&CleanerValidator{Reader: mgr.GetAPIReader()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-apps-projectsveltos-io-v1alpha1-cleaner,mutating=false,failurePolicy=fail,sideEffects=None,groups=apps.projectsveltos.io,resources=cleaners,verbs=create;update,versions=v1alpha1,name=vcleaner-v1alpha1.projectsveltos.io,admissionReviewVersions=v1
+
+// CleanerValidator rejects Cleaners the controller could not run: an
+// unparseable schedule, Lua scripts that do not compile, missing
+// notification Secrets, inconsistent rollback or transform configuration.
+type CleanerValidator struct {
+	// Reader is used to verify referenced Secrets exist. It should not be
+	// cached, so that the webhook does not watch every Secret.
+	Reader client.Reader
+}
+
+var _ admission.Validator[*appsv1alpha1.Cleaner] = &CleanerValidator{}
+
+// ValidateCreate validates a Cleaner being created.
+func (v *CleanerValidator) ValidateCreate(ctx context.Context, cleaner *appsv1alpha1.Cleaner,
+) (admission.Warnings, error) {
+
+	return nil, v.validate(ctx, cleaner)
+}
+
+// ValidateUpdate validates a Cleaner being updated. Only spec changes are
+// validated, so that a Cleaner that became invalid, for instance because a
+// notification Secret was removed, can still have its metadata updated and
+// be deleted.
+func (v *CleanerValidator) ValidateUpdate(ctx context.Context, oldCleaner, newCleaner *appsv1alpha1.Cleaner,
+) (admission.Warnings, error) {
+
+	if !newCleaner.DeletionTimestamp.IsZero() ||
+		equality.Semantic.DeepEqual(oldCleaner.Spec, newCleaner.Spec) {
+		return nil, nil
+	}
+
+	return nil, v.validate(ctx, newCleaner)
+}
+
+// ValidateDelete allows every deletion.
+func (v *CleanerValidator) ValidateDelete(_ context.Context, _ *appsv1alpha1.Cleaner,
+) (admission.Warnings, error) {
+
+	return nil, nil
+}
+
+func (v *CleanerValidator) validate(ctx context.Context, cleaner *appsv1alpha1.Cleaner) error {
+	spec := field.NewPath("spec")
+
+	var errs field.ErrorList
+	if _, err := cron.ParseStandard(cleaner.Spec.Schedule); err != nil {
+		errs = append(errs, field.Invalid(spec.Child("schedule"), cleaner.Spec.Schedule, err.Error()))
+	}
+
+	errs = append(errs, executor.ValidateLuaScripts(cleaner)...)
+	errs = append(errs, validateTransform(cleaner)...)
+
+	if err := executor.ValidateRollbackConfig(cleaner); err != nil {
+		errs = append(errs, field.Invalid(spec.Child("rollback"), cleaner.Spec.Rollback, err.Error()))
+	}
+
+	errs = append(errs, v.validateNotifications(ctx, cleaner)...)
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(appsv1alpha1.GroupVersion.WithKind("Cleaner").GroupKind(), cleaner.Name, errs)
+}
+
+// validateTransform requires a transform script wherever the Transform
+// action is taken.
+func validateTransform(cleaner *appsv1alpha1.Cleaner) field.ErrorList {
+	spec := field.NewPath("spec")
+
+	var errs field.ErrorList
+	if len(cleaner.Spec.Actions) == 0 && cleaner.Spec.Action == appsv1alpha1.ActionTransform &&
+		cleaner.Spec.Transform == "" {
+		errs = append(errs, field.Required(spec.Child("transform"),
+			"transform is required when action is Transform"))
+	}
+	for i := range cleaner.Spec.Actions {
+		step := &cleaner.Spec.Actions[i]
+		if step.Action == appsv1alpha1.ActionTransform && step.Transform == "" {
+			errs = append(errs, field.Required(spec.Child("actions").Index(i).Child("transform"),
+				"transform is required when action is Transform"))
+		}
+	}
+	return errs
+}
+
+// validateNotifications verifies the Secret every notification, but the
+// ones not needing one, references exists.
+func (v *CleanerValidator) validateNotifications(ctx context.Context, cleaner *appsv1alpha1.Cleaner,
+) field.ErrorList {
+
+	var errs field.ErrorList
+	for i := range cleaner.Spec.Notifications {
+		notification := &cleaner.Spec.Notifications[i]
+		if notification.Type == appsv1alpha1.NotificationTypeCleanerReport ||
+			notification.Type == appsv1alpha1.NotificationTypeEvent {
+			continue
+		}
+
+		path := field.NewPath("spec", "notifications").Index(i).Child("notificationRef")
+		ref := notification.NotificationRef
+		if ref == nil {
+			errs = append(errs, field.Required(path,
+				fmt.Sprintf("%s notifications must reference a Secret", notification.Type)))
+			continue
+		}
+		if ref.Kind != "Secret" || ref.APIVersion != "v1" {
+			errs = append(errs, field.Invalid(path, ref,
+				fmt.Sprintf("%s notifications must reference a Secret", notification.Type)))
+			continue
+		}
+
+		secret := &corev1.Secret{}
+		err := v.Reader.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, secret)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				errs = append(errs, field.NotFound(path, fmt.Sprintf("%s/%s", ref.Namespace, ref.Name)))
+				continue
+			}
+			errs = append(errs, field.InternalError(path, err))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "gianlucam76/k8s-cleaner/api/v1alpha1"
+)
+
+const evaluate = `
+function evaluate()
+  hs = {}
+  hs.matching = true
+  return hs
+end`
+
+func newCleaner() *appsv1alpha1.Cleaner {
+	return &appsv1alpha1.Cleaner{
+		ObjectMeta: metav1.ObjectMeta{Name: "cleaner"},
+		Spec: appsv1alpha1.CleanerSpec{
+			Schedule: "0 * * * *",
+			Action:   appsv1alpha1.ActionDelete,
+			ResourcePolicySet: appsv1alpha1.ResourcePolicySet{
+				ResourceSelectors: []appsv1alpha1.ResourceSelector{
+					{Kind: "Pod", Group: "", Version: "v1", Evaluate: evaluate},
+				},
+			},
+		},
+	}
+}
+
+func newValidator(objects ...runtime.Object) *CleanerValidator {
+	scheme := runtime.NewScheme()
+	Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	return &CleanerValidator{Reader: fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build()}
+}
+
+// causes returns the fields the error returned by the validator rejects.
+func causes(err error) []string {
+	var statusErr *apierrors.StatusError
+	ExpectWithOffset(1, err).To(BeAssignableToTypeOf(statusErr))
+	statusErr = err.(*apierrors.StatusError)
+	fields := make([]string, 0)
+	for _, cause := range statusErr.ErrStatus.Details.Causes {
+		fields = append(fields, cause.Field)
+	}
+	return fields
+}
+
+var _ = Describe("Cleaner validating webhook", func() {
+	It("accepts a valid Cleaner", func() {
+		_, err := newValidator().ValidateCreate(context.TODO(), newCleaner())
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("rejects an unparseable schedule", func() {
+		cleaner := newCleaner()
+		cleaner.Spec.Schedule = "every hour"
+		_, err := newValidator().ValidateCreate(context.TODO(), cleaner)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(causes(err)).To(ConsistOf("spec.schedule"))
+	})
+
+	It("rejects Lua scripts that do not compile or miss the required function", func() {
+		cleaner := newCleaner()
+		cleaner.Spec.ResourcePolicySet.ResourceSelectors[0].Evaluate = "function evaluate(obj"
+		cleaner.Spec.ResourcePolicySet.AggregatedSelection = "function aggregate() end"
+		cleaner.Spec.Action = appsv1alpha1.ActionTransform
+		cleaner.Spec.Transform = "transform = function(obj) return {resource = obj} end"
+		_, err := newValidator().ValidateCreate(context.TODO(), cleaner)
+		Expect(causes(err)).To(ConsistOf(
+			"spec.resourcePolicySet.resourceSelectors[0].evaluate",
+			"spec.resourcePolicySet.aggregatedSelection",
+		))
+	})
+
+	It("accepts Lua scripts defining the required function however they do it", func() {
+		cleaner := newCleaner()
+		cleaner.Spec.ResourcePolicySet.ResourceSelectors[0].Evaluate = `
+			local function build_evaluator()
+				return function(obj) return {matching = true} end
+			end
+			evaluate = build_evaluator()`
+		cleaner.Spec.ResourcePolicySet.AggregatedSelection = `
+			do
+				function evaluate() return {resources = {}} end
+			end`
+		_, err := newValidator().ValidateCreate(context.TODO(), cleaner)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("requires a transform script for the Transform action", func() {
+		cleaner := newCleaner()
+		cleaner.Spec.Action = appsv1alpha1.ActionTransform
+		_, err := newValidator().ValidateCreate(context.TODO(), cleaner)
+		Expect(causes(err)).To(ConsistOf("spec.transform"))
+
+		cleaner = newCleaner()
+		cleaner.Spec.Actions = []appsv1alpha1.ActionStep{
+			{Name: "scan", Action: appsv1alpha1.ActionScan},
+			{Name: "transform", Action: appsv1alpha1.ActionTransform},
+		}
+		_, err = newValidator().ValidateCreate(context.TODO(), cleaner)
+		Expect(causes(err)).To(ConsistOf("spec.actions[1].transform"))
+	})
+
+	It("requires a CleanerReport notification when Rollback is enabled", func() {
+		cleaner := newCleaner()
+		cleaner.Spec.Rollback = &appsv1alpha1.RollbackOptions{}
+		_, err := newValidator().ValidateCreate(context.TODO(), cleaner)
+		Expect(causes(err)).To(ConsistOf("spec.rollback"))
+
+		cleaner.Spec.Notifications = []appsv1alpha1.Notification{
+			{Name: "report", Type: appsv1alpha1.NotificationTypeCleanerReport},
+		}
+		_, err = newValidator().ValidateCreate(context.TODO(), cleaner)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("requires notification Secrets to exist", func() {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "slack"}}
+		cleaner := newCleaner()
+		cleaner.Spec.Notifications = []appsv1alpha1.Notification{
+			{Name: "event", Type: appsv1alpha1.NotificationTypeEvent},
+			{Name: "slack", Type: appsv1alpha1.NotificationTypeSlack, NotificationRef: &corev1.ObjectReference{
+				APIVersion: "v1", Kind: "Secret", Namespace: "default", Name: "slack"}},
+			{Name: "teams", Type: appsv1alpha1.NotificationTypeTeams},
+			{Name: "discord", Type: appsv1alpha1.NotificationTypeDiscord, NotificationRef: &corev1.ObjectReference{
+				APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "discord"}},
+		}
+		_, err := newValidator(secret).ValidateCreate(context.TODO(), cleaner)
+		Expect(causes(err)).To(ConsistOf(
+			"spec.notifications[2].notificationRef",
+			"spec.notifications[3].notificationRef",
+		))
+
+		_, err = newValidator().ValidateCreate(context.TODO(), cleaner)
+		Expect(causes(err)).To(ConsistOf(
+			"spec.notifications[1].notificationRef",
+			"spec.notifications[2].notificationRef",
+			"spec.notifications[3].notificationRef",
+		))
+	})
+
+	It("only validates updates changing the spec", func() {
+		invalid := newCleaner()
+		invalid.Spec.Schedule = "every hour"
+
+		updated := invalid.DeepCopy()
+		updated.Finalizers = []string{appsv1alpha1.CleanerFinalizer}
+		_, err := newValidator().ValidateUpdate(context.TODO(), invalid, updated)
+		Expect(err).ToNot(HaveOccurred())
+
+		updated = invalid.DeepCopy()
+		updated.Spec.Action = appsv1alpha1.ActionScan
+		_, err = newValidator().ValidateUpdate(context.TODO(), invalid, updated)
+		Expect(causes(err)).To(ConsistOf("spec.schedule"))
+	})
+})
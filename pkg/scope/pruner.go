@@ -21,6 +21,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -95,3 +96,20 @@ func (s *CleanerScope) SetNextScheduleTime(lastRunTime *metav1.Time) {
 func (s *CleanerScope) SetFailureMessage(failureMessage *string) {
 	s.Cleaner.Status.FailureMessage = failureMessage
 }
+
+// SetLastRunStatistics sets LastRunStatistics field
+func (s *CleanerScope) SetLastRunStatistics(statistics *appsv1alpha1.RunStatistics) {
+	s.Cleaner.Status.LastRunStatistics = statistics
+}
+
+// SetCondition adds or updates condition in Conditions, stamping it with the
+// Cleaner generation. LastTransitionTime only changes when Status does.
+func (s *CleanerScope) SetCondition(conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&s.Cleaner.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: s.Cleaner.Generation,
+	})
+}